@@ -0,0 +1,81 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import "testing"
+
+// Results before and after removing strings.Title and pre-sizing the
+// buffer in Error(), and joining list errors with strings.Join:
+//
+//	                                   before                after
+//	MgmtErrorError               695 ns  432 B  4 allocs  179 ns  176 B  1 allocs
+//	NonUniqueErrorError         1714 ns  192 B  7 allocs 1033 ns  176 B  6 allocs
+//	PathAmbiguousErrorError      996 ns  352 B  5 allocs  759 ns  336 B  4 allocs
+//	UnknownElementErrorError     394 ns  144 B  3 allocs  218 ns  128 B  2 allocs
+//	ExecErrorGetMessage          814 ns  192 B  6 allocs  707 ns  192 B  6 allocs
+//	TooManyElementsGetMessage    675 ns   96 B  4 allocs  554 ns   96 B  4 allocs
+//	MgmtErrorListError         11512 ns 11824 B 46 allocs 2907 ns 3712 B 12 allocs
+
+func benchmarkError(b *testing.B, err error) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = err.Error()
+	}
+}
+
+func BenchmarkMgmtErrorError(b *testing.B) {
+	err := NewOperationFailedApplicationError()
+	err.Path = "/interfaces/dataplane/dp0s3/address"
+	benchmarkError(b, err)
+}
+
+func BenchmarkNonUniqueErrorError(b *testing.B) {
+	err := NewNonUniqueError([]string{"/a/b/c", "/a/b/d"})
+	err.Path = "/a/b"
+	benchmarkError(b, err)
+}
+
+func BenchmarkPathAmbiguousErrorError(b *testing.B) {
+	benchmarkError(b, NewPathAmbiguousError([]string{"s"},
+		map[string]string{
+			"system":   "System parameters",
+			"service":  "Services",
+			"security": "Security",
+		}))
+}
+
+func BenchmarkUnknownElementErrorError(b *testing.B) {
+	err := NewUnknownElementApplicationError("bar")
+	err.Path = "/foo"
+	benchmarkError(b, err)
+}
+
+func BenchmarkExecErrorGetMessage(b *testing.B) {
+	err := NewExecErrorWithStatus([]string{"usr", "bin", "app"},
+		"core dumped", 139)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = err.GetMessage()
+	}
+}
+
+func BenchmarkTooManyElementsErrorGetMessage(b *testing.B) {
+	err := NewTooManyElementsErrorWithCount("/foo", 5, 4)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = err.GetMessage()
+	}
+}
+
+func BenchmarkMgmtErrorListError(b *testing.B) {
+	var list MgmtErrorList
+	for i := 0; i < 10; i++ {
+		err := NewOperationFailedApplicationError()
+		err.Path = "/interfaces/dataplane/dp0s3/address"
+		list.MgmtErrorListAppend(err)
+	}
+	benchmarkError(b, list)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"fmt"
+	"reflect"
+)
+
+func diffField(name, got, want string) []string {
+	if got == want {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s: got '%s' want '%s'", name, got, want)}
+}
+
+func infoTagName(t MgmtErrorInfoTag) string {
+	if sameInfoNamespace(t.XMLName.Space, netconf_namespace) {
+		return t.XMLName.Local
+	}
+	return "{" + t.XMLName.Space + "}" + t.XMLName.Local
+}
+
+// Diff returns a description of each difference between the error and
+// other, eg "tag: got 'in-use' want 'invalid-value'", where got is the
+// value in the error and want that in other. It returns nil if they
+// are Equal.
+//
+// Info tags are compared in order.
+func (e *MgmtError) Diff(other *MgmtError) []string {
+	if e == nil || other == nil {
+		if e == other {
+			return nil
+		}
+		if e == nil {
+			return []string{"error: got nil want non-nil"}
+		}
+		return []string{"error: got non-nil want nil"}
+	}
+
+	var diffs []string
+	diffs = append(diffs, diffField("type", e.Typ, other.Typ)...)
+	diffs = append(diffs, diffField("tag", e.Tag, other.Tag)...)
+	diffs = append(diffs,
+		diffField("severity", e.Severity, other.Severity)...)
+	diffs = append(diffs, diffField("app-tag", e.AppTag, other.AppTag)...)
+	diffs = append(diffs, diffField("path", e.Path, other.Path)...)
+	diffs = append(diffs, diffField("message", e.Message, other.Message)...)
+
+	if len(e.Info) != len(other.Info) {
+		diffs = append(diffs, fmt.Sprintf("info: got %d tags want %d",
+			len(e.Info), len(other.Info)))
+	}
+	for i := 0; i < len(e.Info) && i < len(other.Info); i++ {
+		got, want := e.Info[i], other.Info[i]
+		prefix := fmt.Sprintf("info[%d].", i)
+		diffs = append(diffs, diffField(prefix+"name",
+			infoTagName(got), infoTagName(want))...)
+		diffs = append(diffs,
+			diffField(prefix+"value", got.Value, want.Value)...)
+		if len(got.Attrs) != 0 || len(want.Attrs) != 0 {
+			if !reflect.DeepEqual(got.Attrs, want.Attrs) {
+				diffs = append(diffs, prefix+"attrs differ")
+			}
+		}
+	}
+	return diffs
+}
+
+// Equal reports whether the error and other have the same content, ie
+// there are no differences reported by Diff.
+func (e *MgmtError) Equal(other *MgmtError) bool {
+	return len(e.Diff(other)) == 0
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestMgmtErrorDiff(t *testing.T) {
+	withAttrs := NewBadAttrProtocolError("attr", "elem").MgmtError
+	withAttrs.Info[0].Attrs = []xml.Attr{
+		{Name: xml.Name{Local: "unit"}, Value: "bytes"}}
+
+	tests := []struct {
+		name      string
+		got, want *MgmtError
+		expected  []string
+	}{
+		{
+			name:     "equal",
+			got:      NewBadAttrProtocolError("attr", "elem").MgmtError,
+			want:     NewBadAttrProtocolError("attr", "elem").MgmtError,
+			expected: nil,
+		},
+		{
+			name:     "both nil",
+			expected: nil,
+		},
+		{
+			name: "nil",
+			got:  NewInUseProtocolError().MgmtError,
+			expected: []string{
+				"error: got non-nil want nil",
+			},
+		},
+		{
+			name: "tag and type",
+			got:  NewInUseProtocolError().MgmtError,
+			want: NewInvalidValueApplicationError().MgmtError,
+			expected: []string{
+				"type: got 'protocol' want 'application'",
+				"tag: got 'in-use' want 'invalid-value'",
+				"message: got '" + msg_nc_in_use + "' want '" +
+					msg_nc_invalid_value + "'",
+			},
+		},
+		{
+			name: "path",
+			got:  NewMustViolationError().WithPath("/a"),
+			want: NewMustViolationError().WithPath("/b"),
+			expected: []string{
+				"path: got '/a' want '/b'",
+			},
+		},
+		{
+			name: "info",
+			got:  NewBadAttrProtocolError("attr", "elem").MgmtError,
+			want: NewBadAttrProtocolError("other", "elem").MgmtError,
+			expected: []string{
+				"info[0].value: got 'attr' want 'other'",
+			},
+		},
+		{
+			name: "info count",
+			got:  NewBadAttrProtocolError("attr", "elem").MgmtError,
+			want: NewBadElementProtocolError("elem").MgmtError,
+			expected: []string{
+				"tag: got 'bad-attribute' want 'bad-element'",
+				"message: got '" + msg_nc_bad_attribute + "' want '" +
+					msg_nc_bad_element + "'",
+				"info: got 2 tags want 1",
+				"info[0].name: got 'bad-attribute' want 'bad-element'",
+				"info[0].value: got 'attr' want 'elem'",
+			},
+		},
+		{
+			name: "info namespace",
+			got:  newMgmtError().WithRelatedPaths("/a"),
+			want: func() *MgmtError {
+				e := newMgmtError()
+				e.Info = append(e.Info, *NewMgmtErrorInfoTag(
+					"", related_path_info.String(), "/a"))
+				return e
+			}(),
+			expected: []string{
+				"info[0].name: got '{" + VyattaNamespace +
+					"}related-path' want 'related-path'",
+			},
+		},
+		{
+			name: "info attrs",
+			got:  withAttrs,
+			want: NewBadAttrProtocolError("attr", "elem").MgmtError,
+			expected: []string{
+				"info[0].attrs differ",
+			},
+		},
+	}
+	for _, test := range tests {
+		diffs := test.got.Diff(test.want)
+		if !reflect.DeepEqual(test.expected, diffs) {
+			t.Errorf("%s: unexpected differences\n  expect: %q\n  got:    %q",
+				test.name, test.expected, diffs)
+		}
+		if equal := test.got.Equal(test.want); equal != (diffs == nil) {
+			t.Errorf("%s: Equal returned %v", test.name, equal)
+		}
+	}
+}
@@ -27,5 +27,10 @@
 	about the error so that a higher layer can use that
 	information.
 
+	The package settings, ie its exported variables such as Messages
+	and the Register and Set functions, apply to the whole process.
+	They are not synchronised, so they are expected to be set from an
+	init function, before errors are created, formatted or decoded.
+
 */
 package mgmterror
@@ -148,9 +148,14 @@ func CheckMgmtErrorsInLog(
 		}
 		if len(expWarn.expInfo) > 0 {
 			for _, info := range expWarn.expInfo {
-				if !strings.Contains(logStr, info.XMLName.Space) {
-					t.Fatalf("Syslog doesn't contain info space %s\n",
-						info.XMLName.Space)
+				// An empty space would match anything, so check for
+				// the canonical namespace, or its module name as used
+				// in JSON encoding.
+				ns, module := mgmterror.CanonicalInfoNamespace(
+					info.XMLName.Space)
+				if !strings.Contains(logStr, ns) &&
+					(module == "" || !strings.Contains(logStr, module)) {
+					t.Fatalf("Syslog doesn't contain info space %s\n", ns)
 				}
 				if !strings.Contains(logStr, info.XMLName.Local) {
 					t.Fatalf("Syslog doesn't contain info local %s\n",
@@ -196,16 +201,29 @@ func (eme *ExpMgmtError) Matches(actualErr mgmterror.Formattable) bool {
 	return true
 }
 
+// RequireFormattable returns err as a mgmterror.Formattable, failing
+// the test if it is not one, rather than leaving the caller to panic on
+// a nil interface.
+func RequireFormattable(t *testing.T, err error) mgmterror.Formattable {
+	me, ok := err.(mgmterror.Formattable)
+	if !ok {
+		t.Fatalf("Error does not meet Formattable interface: %T: %v",
+			err, err)
+		return nil
+	}
+	return me
+}
+
 func CheckMgmtErrors(
 	t *testing.T,
 	expMgmtErrs []*ExpMgmtError,
 	actualErrs []error,
 ) {
-	// Check all actual errors were expected.  We assume all actual errors
-	// are mgmterror.Formattable - if not then you're using the wrong test
+	// Check all actual errors were expected.  All actual errors must be
+	// mgmterror.Formattable - if not then you're using the wrong test
 	// function!
 	for _, actErr := range actualErrs {
-		me, _ := actErr.(mgmterror.Formattable)
+		me := RequireFormattable(t, actErr)
 
 		found := false
 		for _, expErr := range expMgmtErrs {
@@ -226,14 +244,7 @@ func CheckMgmtErrors(
 				t.Logf("\tInfo: NS %s:%s, Value %s\n",
 					info.XMLName.Space, info.XMLName.Local, info.Value)
 			}
-			t.Fatalf(
-				"Found unexpected error:\n"+
-					"\tPath:\t%s\n\tMsg:\t%s\n\tTag:\t%s\n"+
-					"\tType:\t%s\n\tSev:\t%s\n\tAppTag:\t%s\n"+
-					"\tInfo:\t%s\n",
-				me.GetPath(), me.GetMessage(), me.GetTag(),
-				me.GetType(), me.GetSeverity(), me.GetAppTag(),
-				me.GetInfo())
+			t.Fatalf("Found unexpected error:\n%s", formatActualError(me))
 			return
 		}
 	}
@@ -242,7 +253,7 @@ func CheckMgmtErrors(
 	for _, expErr := range expMgmtErrs {
 		found := false
 		for _, actErr := range actualErrs {
-			me, _ := actErr.(mgmterror.Formattable)
+			me := RequireFormattable(t, actErr)
 			if !expErr.Matches(me) {
 				continue
 			}
@@ -258,12 +269,39 @@ func CheckMgmtErrors(
 	}
 }
 
-func CheckPath(t *testing.T, err error, expPath string) {
-	me, ok := err.(mgmterror.Formattable)
-	if !ok {
-		t.Fatalf("Error does not meet Formattable interface!")
+// formatActualError renders the fields of an actual error for a test
+// failure.
+func formatActualError(me mgmterror.Formattable) string {
+	return fmt.Sprintf(
+		"\tPath:\t%s\n\tMsg:\t%s\n\tTag:\t%s\n"+
+			"\tType:\t%s\n\tSev:\t%s\n\tAppTag:\t%s\n"+
+			"\tInfo:\t%s\n",
+		me.GetPath(), me.GetMessage(), me.GetTag(),
+		me.GetType(), me.GetSeverity(), me.GetAppTag(),
+		me.GetInfo())
+}
+
+// CheckNoErrors fails the test if there are any errors, dumping each of
+// them in the same format as CheckMgmtErrors. Errors which are not
+// mgmterror.Formattable are dumped with their type and error string.
+func CheckNoErrors(t *testing.T, errs []error) {
+	if len(errs) == 0 {
 		return
 	}
+	var b strings.Builder
+	for i, err := range errs {
+		fmt.Fprintf(&b, "Error %d:\n", i+1)
+		if me, ok := err.(mgmterror.Formattable); ok {
+			b.WriteString(formatActualError(me))
+		} else {
+			fmt.Fprintf(&b, "\t%T: %v\n", err, err)
+		}
+	}
+	t.Fatalf("Expected no errors, found %d:\n%s", len(errs), b.String())
+}
+
+func CheckPath(t *testing.T, err error, expPath string) {
+	me := RequireFormattable(t, err)
 
 	if me.GetPath() != expPath {
 		t.Fatalf("Path mismatch:\nExp:\t'%s'\nGot:\t'%s'\n",
@@ -272,11 +310,7 @@ func CheckPath(t *testing.T, err error, expPath string) {
 }
 
 func CheckMsg(t *testing.T, err error, expMsg string) {
-	me, ok := err.(mgmterror.Formattable)
-	if !ok {
-		t.Fatalf("Error does not meet Formattable interface!")
-		return
-	}
+	me := RequireFormattable(t, err)
 
 	if me.GetMessage() != expMsg {
 		t.Fatalf("Msg mismatch:\nExp:\t'%s'\nGot:\t'%s'\n",
@@ -285,11 +319,7 @@ func CheckMsg(t *testing.T, err error, expMsg string) {
 }
 
 func CheckInfo(t *testing.T, err error, expInfoVal string) {
-	me, ok := err.(mgmterror.Formattable)
-	if !ok {
-		t.Fatalf("Error does not meet Formattable interface!")
-		return
-	}
+	me := RequireFormattable(t, err)
 
 	if expInfoVal == "" && len(me.GetInfo()) == 0 {
 		// Nothing expected, nothing seen.  All clear.
@@ -310,6 +340,59 @@ func CheckInfo(t *testing.T, err error, expInfoVal string) {
 	}
 }
 
+// mgmtErrorFieldsString renders every field of an error, one per line,
+// so that expected and actual errors can be compared as strings. The
+// Message field is rendered rather than GetMessage, which the specific
+// error types compose from the info, and which may be decorated.
+func mgmtErrorFieldsString(me mgmterror.Formattable) string {
+	msg := me.GetMessage()
+	if raw, ok := mgmterror.AsMgmtError(me); ok {
+		msg = raw.Message
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "Type:\t%s\n", me.GetType())
+	fmt.Fprintf(&b, "Tag:\t%s\n", me.GetTag())
+	fmt.Fprintf(&b, "Sev:\t%s\n", me.GetSeverity())
+	fmt.Fprintf(&b, "AppTag:\t%s\n", me.GetAppTag())
+	fmt.Fprintf(&b, "Path:\t%s\n", me.GetPath())
+	fmt.Fprintf(&b, "Msg:\t%s\n", msg)
+	for _, info := range me.GetInfo() {
+		fmt.Fprintf(&b, "Info:\tNS %s:%s, Value %s\n",
+			info.XMLName.Space, info.XMLName.Local, info.Value)
+	}
+	return b.String()
+}
+
+// CheckMgmtErrorExact checks that every field of err, including the
+// full info, exactly matches expected. All mismatching fields are
+// reported.
+func CheckMgmtErrorExact(t *testing.T, err error, expected *mgmterror.MgmtError) {
+	me := RequireFormattable(t, err)
+
+	expStr := mgmtErrorFieldsString(expected)
+	actStr := mgmtErrorFieldsString(me)
+	if expStr == actStr {
+		return
+	}
+
+	expLines := strings.Split(expStr, "\n")
+	actLines := strings.Split(actStr, "\n")
+	for i := 0; i < len(expLines) || i < len(actLines); i++ {
+		var expLine, actLine string
+		if i < len(expLines) {
+			expLine = expLines[i]
+		}
+		if i < len(actLines) {
+			actLine = actLines[i]
+		}
+		if expLine != actLine {
+			t.Logf("Mismatch:\nExp:\t'%s'\nGot:\t'%s'\n", expLine, actLine)
+		}
+	}
+	CheckStringDivergence(t, expStr, actStr)
+}
+
 func checkInfoMatchesNonFatal(
 	me mgmterror.Formattable,
 	expInfo []*mgmterror.MgmtErrorInfoTag,
@@ -355,13 +438,26 @@ type TestError struct {
 	rpcMsgs   []string
 	setMsg    string
 	setSuffix string // used when set error doesn't end with 'is not valid'
+	mgmtErr   func() mgmterror.Formattable
+}
+
+// ToMgmtError returns the error described by the TestError, built with
+// the mgmterror constructors, so that a test can check the expected
+// strings are those produced from a real error. It fails the test if
+// there is no constructor for the error.
+func (te *TestError) ToMgmtError() mgmterror.Formattable {
+	if te.mgmtErr == nil {
+		te.t.Fatalf("Test error has no equivalent MgmtError")
+		return nil
+	}
+	return te.mgmtErr()
 }
 
 func (te *TestError) CliErrorStrings() []string {
 
 	pathSlice := getPathSlice(te.t, te.path, "generic error")
 
-	retStr := []string{fmt.Sprintf("%s", mgmterror.ErrPath(pathSlice))}
+	retStr := []string{fmt.Sprintf("%s", mgmterror.ErrPathCLI(pathSlice))}
 	return append(retStr, te.cliMsgs...)
 }
 
@@ -377,7 +473,7 @@ func (te *TestError) RpcErrorStrings() []string {
 
 	pathSlice := getPathSlice(te.t, te.path, "rpc error")
 
-	retStr := []string{fmt.Sprintf("%s", mgmterror.ErrPath(pathSlice))}
+	retStr := []string{fmt.Sprintf("%s", mgmterror.ErrPathRPC(pathSlice))}
 	return append(retStr, te.rpcMsgs...)
 }
 
@@ -397,23 +493,39 @@ func (te *TestError) SetCliErrorStrings() []string {
 
 	pathSlice := getPathSlice(te.t, te.path, "generic error")
 	if te.setMsg == noMsgPrinted {
-		return []string{fmt.Sprintf("%s %s %s",
-			configPathStr, mgmterror.ErrPath(pathSlice), isNotValidStr),
-		}
+		return []string{setErrorString(pathSlice)}
 	}
 	if te.setSuffix == "" {
-		return []string{fmt.Sprintf("%s %s %s",
-			configPathStr, mgmterror.ErrPath(pathSlice), isNotValidStr),
-			te.setMsg,
-		}
+		return []string{setErrorString(pathSlice), te.setMsg}
 	}
 
-	return []string{fmt.Sprintf("%s %s %s",
-		configPathStr, mgmterror.ErrPath(pathSlice), te.setSuffix),
+	return []string{
+		mgmterror.NewConfigPathInvalidError(pathSlice, te.setSuffix).
+			SetErrorString(),
 		te.setMsg,
 	}
 }
 
+// setErrorString - generate the CLI set error for an invalid path
+//
+// This is the same as the CLI generates from the mgmterror, eg:
+//
+// Configuration path: <path-to-node> [<last-elem>] is not valid
+func setErrorString(pathSlice []string) string {
+	return unknownElementError(pathSlice).SetErrorString()
+}
+
+// unknownElementError - the error for the last element of the path
+// being invalid below the rest of the path
+func unknownElementError(
+	pathSlice []string,
+) *mgmterror.UnknownElementApplicationError {
+	last := len(pathSlice) - 1
+	err := mgmterror.NewUnknownElementApplicationError(pathSlice[last])
+	err.Path = "/" + strings.Join(pathSlice[:last], "/")
+	return err
+}
+
 func (te *TestError) RawErrorStrings() []string {
 
 	retStr := []string{te.path}
@@ -435,6 +547,10 @@ func NewAccessDeniedError(
 		path: path,
 		rawMsgs: []string{"Access to the requested protocol operation " +
 			"or data model is denied because authorization failed."},
+		mgmtErr: func() mgmterror.Formattable {
+			return mgmterror.NewAccessDeniedApplicationError().
+				WithPath(path)
+		},
 	}
 }
 
@@ -461,6 +577,10 @@ func NewInvalidNodeError(
 		cliMsgs: []string{"Configuration path", "is not valid"},
 		rpcMsgs: []string{"is not valid"},
 		setMsg:  noMsgPrinted,
+		mgmtErr: func() mgmterror.Formattable {
+			return unknownElementError(
+				getPathSlice(t, path, "invalid node"))
+		},
 	}
 }
 
@@ -534,6 +654,9 @@ func NewInvalidPathError(
 		rawMsgs: []string{fmt.Sprintf("%s: %s", path, pathIsInvalidStr)},
 		cliMsgs: []string{"TBD"},
 		setMsg:  pathIsInvalidStr,
+		mgmtErr: func() mgmterror.Formattable {
+			return mgmterror.NewInvalidPathError(path)
+		},
 	}
 }
 
@@ -625,6 +748,13 @@ func NewLeafrefError(
 		cliMsgs: []string{
 			leafrefErrorStr, joinPathWithSpaces(
 				getPathSlice(t, leafrefPath, "leafref"))},
+		// NewLeafrefMismatchError does not keep the leafref path, and
+		// decodes as an InstanceRequiredError as it has the same tag
+		// and app-tag, so the target is recorded with the latter.
+		mgmtErr: func() mgmterror.Formattable {
+			return mgmterror.NewInstanceRequiredErrorFor(path,
+				leafrefPath)
+		},
 	}
 }
 
@@ -650,13 +780,13 @@ func NewMissingMandatoryNodeError(
 		t.Fatalf("Cannot have empty path for missing mandatory node error")
 		return nil
 	}
+	parent := strings.Join(pathSlice[:len(pathSlice)-1], "/")
+	msg := missingMandatoryStr + " " + pathSlice[len(pathSlice)-1]
 	return &TestError{
-		t:    t,
-		path: strings.Join(pathSlice[:len(pathSlice)-1], "/"),
-		rawMsgs: []string{
-			missingMandatoryStr + " " + pathSlice[len(pathSlice)-1]},
-		cliMsgs: []string{
-			missingMandatoryStr + " " + pathSlice[len(pathSlice)-1]},
+		t:       t,
+		path:    parent,
+		rawMsgs: []string{msg},
+		cliMsgs: []string{msg},
 	}
 }
 
@@ -670,6 +800,9 @@ func NewNodeDoesntExistError(
 		rawMsgs: []string{nodeDoesntExistStr},
 		cliMsgs: []string{nodeDoesntExistStr},
 		setMsg:  nodeDoesntExistStr,
+		mgmtErr: func() mgmterror.Formattable {
+			return mgmterror.NewDataMissingFor("delete", path)
+		},
 	}
 }
 
@@ -683,6 +816,9 @@ func NewNodeExistsError(
 		rawMsgs: []string{nodeExistsStr},
 		cliMsgs: []string{nodeExistsStr},
 		setMsg:  nodeExistsStr,
+		mgmtErr: func() mgmterror.Formattable {
+			return mgmterror.NewDataExistsFor("create", path)
+		},
 	}
 }
 
@@ -755,10 +891,14 @@ func NewSchemaMismatchError(
 	path string,
 ) *TestError {
 	return &TestError{
-		t:       t,
-		path:    path,
-		rawMsgs: []string{"Doesn't match schema"},
+		t:    t,
+		path: path,
+		rawMsgs: []string{
+			mgmterror.NewSchemaMismatchError(path).GetMessage()},
 		cliMsgs: []string{"TBD"}, // TODO
+		mgmtErr: func() mgmterror.Formattable {
+			return mgmterror.NewSchemaMismatchError(path)
+		},
 	}
 }
 
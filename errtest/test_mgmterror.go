@@ -243,7 +243,7 @@ func genInvalidPathErrMsg(t *testing.T, data []string) string {
 		t.Fatalf("Invalid path error must have single data entry.\n")
 		return ""
 	}
-	path := mgmterror.ErrPath(strings.Split(data[0], " "))
+	path := mgmterror.ErrPathCLI(strings.Split(data[0], " "))
 	return fmt.Sprintf("Configuration path: %s is not valid", path)
 }
 
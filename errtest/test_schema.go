@@ -0,0 +1,177 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+//
+// Structural check of marshalled errors against the RFC6241 rpc-error
+// content model.
+
+package errtest
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+const netconfNamespace = "urn:ietf:params:xml:ns:netconf:base:1.0"
+
+// Children of rpc-error, in the order required by RFC6241 Appendix B
+var rpcErrorElems = []string{
+	"error-type",
+	"error-tag",
+	"error-severity",
+	"error-app-tag",
+	"error-path",
+	"error-message",
+	"error-info",
+}
+
+var requiredRpcErrorElems = []string{
+	"error-type",
+	"error-tag",
+	"error-severity",
+}
+
+var allowedRpcErrorValues = map[string][]string{
+	"error-type": {"transport", "rpc", "protocol", "application"},
+	"error-tag": {
+		"in-use", "invalid-value", "too-big", "missing-attribute",
+		"bad-attribute", "unknown-attribute", "missing-element",
+		"bad-element", "unknown-element", "unknown-namespace",
+		"access-denied", "lock-denied", "resource-denied",
+		"rollback-failed", "data-exists", "data-missing",
+		"operation-not-supported", "operation-failed",
+		"partial-operation", "malformed-message",
+	},
+	"error-severity": {"error", "warning"},
+}
+
+func rpcErrorElemIndex(name string) int {
+	for i, n := range rpcErrorElems {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func isAllowedRpcErrorValue(name, value string) bool {
+	allowed, ok := allowedRpcErrorValues[name]
+	if !ok {
+		return true
+	}
+	for _, a := range allowed {
+		if a == value {
+			return true
+		}
+	}
+	return false
+}
+
+// checkRpcError checks the content of a single rpc-error element, whose
+// start element has already been read.
+func checkRpcError(dec *xml.Decoder) error {
+	last := -1
+	seen := make(map[string]bool)
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch elem := tok.(type) {
+		case xml.EndElement:
+			for _, req := range requiredRpcErrorElems {
+				if !seen[req] {
+					return fmt.Errorf("rpc-error missing %s", req)
+				}
+			}
+			return nil
+		case xml.StartElement:
+			name := elem.Name.Local
+			if elem.Name.Space != netconfNamespace {
+				return fmt.Errorf("%s in namespace %q, expected %s",
+					name, elem.Name.Space, netconfNamespace)
+			}
+			idx := rpcErrorElemIndex(name)
+			if idx < 0 {
+				return fmt.Errorf("unexpected element %s in rpc-error",
+					name)
+			}
+			if idx <= last {
+				return fmt.Errorf("%s out of order or repeated in "+
+					"rpc-error", name)
+			}
+			last = idx
+			seen[name] = true
+			if name == "error-info" {
+				// Content is not constrained by RFC6241
+				if err := dec.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			var value string
+			if err := dec.DecodeElement(&value, &elem); err != nil {
+				return err
+			}
+			if !isAllowedRpcErrorValue(name, strings.TrimSpace(value)) {
+				return fmt.Errorf("invalid %s value %q", name, value)
+			}
+		}
+	}
+}
+
+// checkNetconfSchema checks data, which is either a sequence of
+// rpc-error elements or an rpc-reply containing them.
+func checkNetconfSchema(data []byte) error {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	found := false
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		elem, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch {
+		case elem.Name.Space != netconfNamespace:
+			return fmt.Errorf("%s in namespace %q, expected %s",
+				elem.Name.Local, elem.Name.Space, netconfNamespace)
+		case elem.Name.Local == "rpc-reply":
+			// Check the rpc-errors inside
+		case elem.Name.Local == "rpc-error":
+			found = true
+			if err := checkRpcError(dec); err != nil {
+				return err
+			}
+		default:
+			if err := dec.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+	if !found {
+		return fmt.Errorf("no rpc-error found")
+	}
+	return nil
+}
+
+// ValidateAgainstNetconfSchema checks that the marshalled errors in xml
+// conform to the rpc-error content model of RFC6241: the required
+// elements are present, the elements are in order and in the NETCONF
+// namespace, and the enumerated values are valid. The content of
+// error-info is not checked.
+func ValidateAgainstNetconfSchema(t *testing.T, xml []byte) {
+	if err := checkNetconfSchema(xml); err != nil {
+		t.Errorf("Invalid NETCONF rpc-error: %v\n%s", err, xml)
+	}
+}
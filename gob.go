@@ -0,0 +1,69 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+)
+
+// gobMgmtError has the content of a MgmtError carried by its gob
+// encoding, including whether an empty app-tag or path was present.
+type gobMgmtError struct {
+	Typ         string
+	Tag         string
+	Severity    string
+	AppTag      string
+	Path        string
+	Message     string
+	Info        MgmtErrorInfo
+	NoPath      bool
+	EmptyAppTag bool
+	EmptyPath   bool
+}
+
+// GobEncode encodes the error for encoding/gob. The specific error
+// types are encoded as their MgmtError.
+func (e *MgmtError) GobEncode() ([]byte, error) {
+	var b bytes.Buffer
+	err := gob.NewEncoder(&b).Encode(gobMgmtError{
+		Typ:         e.Typ,
+		Tag:         e.Tag,
+		Severity:    e.Severity,
+		AppTag:      e.AppTag,
+		Path:        e.Path,
+		Message:     e.Message,
+		Info:        e.Info,
+		NoPath:      e.noPath,
+		EmptyAppTag: e.emptyAppTag,
+		EmptyPath:   e.emptyPath,
+	})
+	return b.Bytes(), err
+}
+
+// GobDecode decodes an error encoded by GobEncode.
+func (e *MgmtError) GobDecode(data []byte) error {
+	if e == nil {
+		return errors.New("cannot decode into nil MgmtError")
+	}
+	var fields gobMgmtError
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&fields); err != nil {
+		return err
+	}
+	e.setXMLName()
+	e.Typ = fields.Typ
+	e.Tag = fields.Tag
+	e.Severity = fields.Severity
+	e.AppTag = fields.AppTag
+	e.Path = fields.Path
+	e.Message = fields.Message
+	e.Info = fields.Info
+	e.noPath = fields.NoPath
+	e.emptyAppTag = fields.EmptyAppTag
+	e.emptyPath = fields.EmptyPath
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/xml"
+	"reflect"
+	"testing"
+
+	"github.com/kr/pretty"
+)
+
+func TestGobRoundTrip(t *testing.T) {
+	withInfo := NewMgmtErrorInfoTag("urn:example", "detail", "value")
+	withInfo.Attrs = []xml.Attr{{
+		Name:  xml.Name{Space: "urn:example", Local: "lang"},
+		Value: "en",
+	}}
+	plain := newMgmtError()
+	plain.Typ = application.String()
+	plain.Tag = "operation-failed"
+	plain.Severity = "warning"
+	plain.Message = "plain"
+	plain.Info = MgmtErrorInfo{*withInfo}
+	plain.emptyPath = true
+
+	errs := []error{
+		plain,
+		NewInUseProtocolError(),
+		NewMissingAttrApplicationError("message-id", "rpc"),
+		NewNonUniqueError([]string{"/a/b", "/a/c"}),
+		NewExecError([]string{"usr", "bin", "app"}, "failed"),
+		NewDataMissingFor("delete", "/system/ntp"),
+	}
+	for _, err := range errs {
+		var b bytes.Buffer
+		if e := gob.NewEncoder(&b).Encode(err); e != nil {
+			t.Fatalf("%T: encode error: %v", err, e)
+		}
+		decoded := reflect.New(reflect.TypeOf(err).Elem())
+		if e := gob.NewDecoder(&b).DecodeValue(decoded); e != nil {
+			t.Fatalf("%T: decode error: %v", err, e)
+		}
+		if !reflect.DeepEqual(err, decoded.Interface()) {
+			t.Errorf("%T: unexpected error decoded", err)
+			t.Logf("Expected: %# v", pretty.Formatter(err))
+			t.Logf("Result:   %# v", pretty.Formatter(decoded.Interface()))
+		}
+	}
+}
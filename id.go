@@ -0,0 +1,42 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// WithID records an identifier for the error, so that a client can
+// refer to a specific error from a list, eg when reporting a problem.
+// It replaces any id already recorded. No id is recorded by default.
+func (e *MgmtError) WithID(id string) *MgmtError {
+	e.setVyattaInfo(error_id_info, id)
+	return e
+}
+
+// WithGeneratedID records a random (version 4) UUID as the id of the
+// error, as WithID does.
+func (e *MgmtError) WithGeneratedID() *MgmtError {
+	return e.WithID(newUUID())
+}
+
+// GetID returns the id recorded with WithID, or "" if there is none.
+func (e *MgmtError) GetID() string {
+	return e.Info.FindMgmtErrorTag(VyattaNamespace, error_id_info.String())
+}
+
+// newUUID returns a random UUID, as defined by RFC4122 section 4.4.
+func newUUID() string {
+	var u [16]byte
+	if _, err := rand.Read(u[:]); err != nil {
+		panic(err)
+	}
+	u[6] = (u[6] & 0x0f) | 0x40 // Version 4
+	u[8] = (u[8] & 0x3f) | 0x80 // RFC4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10],
+		u[10:])
+}
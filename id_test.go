@@ -0,0 +1,67 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"regexp"
+	"testing"
+)
+
+func TestWithID(t *testing.T) {
+	err := NewInUseApplicationError().MgmtError
+	if id := err.GetID(); id != "" {
+		t.Errorf("Unexpected default id: %s", id)
+	}
+	if len(err.Info) != 0 {
+		t.Errorf("Unexpected info by default: %v", err.Info)
+	}
+
+	err.WithID("first").WithID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	if len(err.Info) != 1 {
+		t.Errorf("Id not replaced: %v", err.Info)
+	}
+
+	marshal, e := json.Marshal(err)
+	if e != nil {
+		t.Fatalf("Marshal error: %v", e)
+	}
+	fromJSON := newMgmtError()
+	if e := json.Unmarshal(marshal, fromJSON); e != nil {
+		t.Fatalf("Unmarshal error: %v", e)
+	}
+	if id := fromJSON.GetID(); id != "01ARZ3NDEKTSV4RRFFQ69G5FAV" {
+		t.Errorf("Unexpected id from JSON: %s", id)
+	}
+
+	marshal, e = xml.Marshal(err)
+	if e != nil {
+		t.Fatalf("Marshal XML error: %v", e)
+	}
+	fromXML := newMgmtError()
+	if e := xml.Unmarshal(marshal, fromXML); e != nil {
+		t.Fatalf("Unmarshal XML error: %v", e)
+	}
+	if id := fromXML.GetID(); id != "01ARZ3NDEKTSV4RRFFQ69G5FAV" {
+		t.Errorf("Unexpected id from XML: %s", id)
+	}
+}
+
+func TestWithGeneratedID(t *testing.T) {
+	uuid := regexp.MustCompile(
+		`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	first := NewInUseApplicationError().WithGeneratedID().GetID()
+	second := NewInUseApplicationError().WithGeneratedID().GetID()
+	for _, id := range []string{first, second} {
+		if !uuid.MatchString(id) {
+			t.Errorf("Generated id is not a UUID: %s", id)
+		}
+	}
+	if first == second {
+		t.Errorf("Generated ids are not unique: %s", first)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"encoding/json"
+	"encoding/xml"
+)
+
+// marshalJSONIndent encodes v as JSON, indented as json.MarshalIndent
+// does unless both prefix and indent are empty, giving compact output.
+func marshalJSONIndent(v interface{}, prefix, indent string) ([]byte, error) {
+	if prefix == "" && indent == "" {
+		return json.Marshal(v)
+	}
+	return json.MarshalIndent(v, prefix, indent)
+}
+
+// marshalXMLIndent encodes v as XML, indented as xml.MarshalIndent
+// does unless both prefix and indent are empty, giving compact output.
+func marshalXMLIndent(v interface{}, prefix, indent string) ([]byte, error) {
+	if prefix == "" && indent == "" {
+		return xml.Marshal(v)
+	}
+	return xml.MarshalIndent(v, prefix, indent)
+}
+
+// MarshalJSONIndent encodes the error as JSON, with each element on a
+// new line starting with prefix and indented by indent. If both are
+// empty, the output is compact, as from json.Marshal.
+func (e *MgmtError) MarshalJSONIndent(prefix, indent string) ([]byte, error) {
+	return marshalJSONIndent(e, prefix, indent)
+}
+
+// MarshalXMLIndent encodes the error as XML, with each element on a new
+// line starting with prefix and indented by indent. If both are empty,
+// the output is compact, as required for NETCONF.
+func (e *MgmtError) MarshalXMLIndent(prefix, indent string) ([]byte, error) {
+	return marshalXMLIndent(e, prefix, indent)
+}
+
+// MarshalJSONIndent encodes the list as MgmtError.MarshalJSONIndent
+// does a single error.
+func (e MgmtErrorList) MarshalJSONIndent(prefix, indent string) ([]byte, error) {
+	return marshalJSONIndent(e, prefix, indent)
+}
+
+// MarshalXMLIndent encodes the list as MgmtError.MarshalXMLIndent does
+// a single error.
+func (e MgmtErrorList) MarshalXMLIndent(prefix, indent string) ([]byte, error) {
+	return marshalXMLIndent(e, prefix, indent)
+}
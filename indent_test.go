@@ -0,0 +1,81 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+)
+
+type marshalIndentFn func(prefix, indent string) ([]byte, error)
+
+func checkMarshalIndent(t *testing.T, name string, fn marshalIndentFn,
+	expIndented, expCompact []byte) {
+
+	indented, err := fn("", "\t")
+	if err != nil {
+		t.Fatalf("%s: indented marshal error: %v", name, err)
+	}
+	if !bytes.Equal(expIndented, indented) {
+		t.Errorf("%s: unexpected indented output\n%s", name, indented)
+	}
+	compact, err := fn("", "")
+	if err != nil {
+		t.Fatalf("%s: compact marshal error: %v", name, err)
+	}
+	if !bytes.Equal(expCompact, compact) {
+		t.Errorf("%s: unexpected compact output\n%s", name, compact)
+	}
+	if len(compact) >= len(indented) {
+		t.Errorf("%s: compact output of %d bytes not smaller than "+
+			"indented output of %d bytes", name, len(compact),
+			len(indented))
+	}
+	if bytes.ContainsAny(compact, "\n\t") {
+		t.Errorf("%s: compact output is indented\n%s", name, compact)
+	}
+}
+
+func TestMarshalIndent(t *testing.T) {
+	err := NewBadAttrProtocolError(bad_attr_value, bad_elem_value)
+	var list MgmtErrorList
+	list.MgmtErrorListAppend(err, NewInUseProtocolError())
+
+	tests := []struct {
+		name   string
+		v      interface{}
+		jsonFn marshalIndentFn
+		xmlFn  marshalIndentFn
+	}{
+		{"error", err, err.MarshalJSONIndent, err.MarshalXMLIndent},
+		{"list", list, list.MarshalJSONIndent, list.MarshalXMLIndent},
+	}
+	for _, test := range tests {
+		expIndented, e := json.MarshalIndent(test.v, "", "\t")
+		if e != nil {
+			t.Fatalf("%s: JSON marshal error: %v", test.name, e)
+		}
+		expCompact, e := json.Marshal(test.v)
+		if e != nil {
+			t.Fatalf("%s: JSON marshal error: %v", test.name, e)
+		}
+		checkMarshalIndent(t, test.name+" JSON", test.jsonFn,
+			expIndented, expCompact)
+
+		expIndented, e = xml.MarshalIndent(test.v, "", "\t")
+		if e != nil {
+			t.Fatalf("%s: XML marshal error: %v", test.name, e)
+		}
+		expCompact, e = xml.Marshal(test.v)
+		if e != nil {
+			t.Fatalf("%s: XML marshal error: %v", test.name, e)
+		}
+		checkMarshalIndent(t, test.name+" XML", test.xmlFn,
+			expIndented, expCompact)
+	}
+}
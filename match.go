@@ -0,0 +1,33 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import "strings"
+
+// Matches any error-tag or error-app-tag in a pattern
+const matchAny = "*"
+
+func matchPatternPart(pattern, value string) bool {
+	return pattern == matchAny || pattern == value
+}
+
+// MatchPattern reports whether the error matches pattern, which is an
+// error-tag and error-app-tag separated by "/", eg
+// "operation-failed/must-violation". Either side may be "*" to match
+// any value, including an error with no app-tag, so that a class of
+// errors can be selected without listing every combination:
+//
+//	operation-failed/*   any operation-failed error
+//	*/must-violation     any error with the must-violation app-tag
+//
+// A pattern without "/" matches on the error-tag alone.
+func (e *MgmtError) MatchPattern(pattern string) bool {
+	tag, appTag := pattern, matchAny
+	if i := strings.Index(pattern, "/"); i >= 0 {
+		tag, appTag = pattern[:i], pattern[i+1:]
+	}
+	return matchPatternPart(tag, e.Tag) && matchPatternPart(appTag, e.AppTag)
+}
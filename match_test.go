@@ -0,0 +1,44 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import "testing"
+
+func TestMatchPattern(t *testing.T) {
+	must := NewMustViolationError().MgmtError
+	inUse := NewInUseApplicationError().MgmtError
+
+	tests := []struct {
+		name    string
+		err     *MgmtError
+		pattern string
+		expect  bool
+	}{
+		{"exact", must, "operation-failed/must-violation", true},
+		{"exact other app-tag", must, "operation-failed/too-many-elements",
+			false},
+		{"exact other tag", must, "in-use/must-violation", false},
+		{"any app-tag", must, "operation-failed/*", true},
+		{"any app-tag other tag", must, "in-use/*", false},
+		{"any tag", must, "*/must-violation", true},
+		{"any tag other app-tag", must, "*/data-not-unique", false},
+		{"both wildcards", must, "*/*", true},
+		{"both wildcards no app-tag", inUse, "*/*", true},
+		{"any app-tag no app-tag", inUse, "in-use/*", true},
+		{"exact no app-tag", inUse, "in-use/", true},
+		{"exact app-tag no app-tag", inUse, "in-use/must-violation", false},
+		{"tag only", must, "operation-failed", true},
+		{"tag only other tag", inUse, "operation-failed", false},
+		{"empty", must, "", false},
+	}
+	for _, test := range tests {
+		if got := test.err.MatchPattern(test.pattern); got != test.expect {
+			t.Errorf("%s: MatchPattern(%q) on %s/%s = %v, expected %v",
+				test.name, test.pattern, test.err.Tag, test.err.AppTag,
+				got, test.expect)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import "strings"
+
+// MessageKind identifies one of the composite messages built from the
+// content of an error, eg by the GetMessage methods of the specific
+// error types. The arguments passed when formatting each kind are
+// listed against it.
+type MessageKind uint
+
+const (
+	// path
+	UnknownElementMessage MessageKind = iota
+	// path
+	PathAmbiguousMessage
+	PossibleCompletionsMessage
+	// path, message
+	InvalidPathMessage
+	// path, exit status, output
+	ExecStatusMessage
+	// entry count, max-elements
+	TooManyElementsMessage
+	// entry count, min-elements
+	TooFewElementsMessage
+	// message, comma separated related paths
+	RelatedPathsMessage
+	// required instance
+	InstanceRequiredMessage
+	// attribute, referenced value
+	InsertFailedMessage
+	// comma separated non-unique paths
+	NonUniquePathsMessage
+	// description of lock holder
+	LockHeldByMessage
+	// number of errors omitted
+	ErrorsOmittedMessage
+	// attribute, element
+	UnknownAttributeMessage
+	// operation, path
+	DataExistsMessage
+	DataMissingMessage
+	// operation
+	OperationNotSupportedMessage
+)
+
+type messageKind struct {
+	name string
+	// Placeholders for the arguments, in order
+	args []string
+}
+
+var messageKinds = map[MessageKind]messageKind{
+	UnknownElementMessage:      {"unknown-element", []string{"path"}},
+	PathAmbiguousMessage:       {"path-ambiguous", []string{"path"}},
+	PossibleCompletionsMessage: {"possible-completions", nil},
+	InvalidPathMessage:         {"invalid-path", []string{"path", "message"}},
+	ExecStatusMessage: {"exec-status",
+		[]string{"path", "status", "output"}},
+	TooManyElementsMessage: {"too-many-elements",
+		[]string{"count", "max-elements"}},
+	TooFewElementsMessage: {"too-few-elements",
+		[]string{"count", "min-elements"}},
+	RelatedPathsMessage: {"related-paths",
+		[]string{"message", "related-paths"}},
+	InstanceRequiredMessage: {"instance-required", []string{"instance"}},
+	InsertFailedMessage: {"insert-failed",
+		[]string{"attribute", "value"}},
+	NonUniquePathsMessage: {"non-unique-paths", []string{"paths"}},
+	LockHeldByMessage:     {"lock-held-by", []string{"holder"}},
+	ErrorsOmittedMessage:  {"errors-omitted", []string{"count"}},
+	UnknownAttributeMessage: {"unknown-attribute",
+		[]string{"attribute", "element"}},
+	DataExistsMessage:  {"data-exists", []string{"operation", "path"}},
+	DataMissingMessage: {"data-missing", []string{"operation", "path"}},
+	OperationNotSupportedMessage: {"operation-not-supported",
+		[]string{"operation"}},
+}
+
+// String returns the name of the kind, as used by
+// RegisterMessageTemplate.
+func (k MessageKind) String() string {
+	return messageKinds[k].name
+}
+
+var englishMessageTemplates = map[MessageKind]string{
+	UnknownElementMessage:        "{path} is not valid",
+	PathAmbiguousMessage:         "{path} is ambiguous",
+	PossibleCompletionsMessage:   "Possible completions:",
+	InvalidPathMessage:           "{path}" + error_msg_separator + "{message}",
+	ExecStatusMessage:            "subtask {path} exited {status}: {output}",
+	TooManyElementsMessage:       "list has {count} entries, maximum {max-elements}",
+	TooFewElementsMessage:        "list has {count} entries, minimum {min-elements}",
+	RelatedPathsMessage:          "{message} Related paths: {related-paths}",
+	InstanceRequiredMessage:      "require-instance: {instance} does not exist",
+	InsertFailedMessage:          "insert failed: {attribute} {value} does not exist",
+	NonUniquePathsMessage:        "Non-unique paths {paths}",
+	LockHeldByMessage:            "Lock is held by {holder}",
+	ErrorsOmittedMessage:         "{count} more errors omitted",
+	UnknownAttributeMessage:      "unexpected attribute '{attribute}' on element '{element}'",
+	DataExistsMessage:            "cannot {operation} {path}: data already exists",
+	DataMissingMessage:           "cannot {operation} {path}: data does not exist",
+	OperationNotSupportedMessage: "operation '{operation}' is not supported",
+}
+
+// renderMessage substitutes the arguments for their named placeholders,
+// eg {path}, in template.
+func renderMessage(kind MessageKind, template string, args ...string) string {
+	names := messageKinds[kind].args
+	pairs := make([]string, 0, 2*len(names))
+	for i, name := range names {
+		if i < len(args) {
+			pairs = append(pairs, "{"+name+"}", args[i])
+		}
+	}
+	return strings.NewReplacer(pairs...).Replace(template)
+}
+
+var messageTemplates = map[string]string{}
+
+// RegisterMessageTemplate replaces the message of the kind with the
+// given name, eg "unknown-element", with template. The template refers
+// to the content of the error with named placeholders, eg
+// "{path} is not valid"; the placeholders for each kind are those used
+// in the default English templates.
+//
+// A registered template takes precedence over Messages.
+func RegisterMessageTemplate(name, template string) {
+	messageTemplates[name] = template
+}
+
+// MessageFormatter formats the composite messages, allowing them to be
+// localised.
+type MessageFormatter interface {
+	// FormatMessage returns the message of the given kind, or "" if
+	// the kind is not handled, in which case the English message is
+	// used.
+	FormatMessage(kind MessageKind, args ...string) string
+}
+
+type englishMessages struct{}
+
+func (englishMessages) FormatMessage(kind MessageKind, args ...string) string {
+	template, ok := englishMessageTemplates[kind]
+	if !ok {
+		return ""
+	}
+	return renderMessage(kind, template, args...)
+}
+
+// Messages formats the composite messages, eg to localise them. It
+// defaults to English.
+var Messages MessageFormatter = englishMessages{}
+
+func formatMessage(kind MessageKind, args ...string) string {
+	if template, ok := messageTemplates[kind.String()]; ok {
+		return renderMessage(kind, template, args...)
+	}
+	if Messages != nil {
+		if msg := Messages.FormatMessage(kind, args...); msg != "" {
+			return msg
+		}
+	}
+	return englishMessages{}.FormatMessage(kind, args...)
+}
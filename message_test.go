@@ -0,0 +1,198 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// Formats the element count messages only, in the style of a
+// translation.
+type testMessageFormatter struct{}
+
+func (testMessageFormatter) FormatMessage(kind MessageKind, args ...string) string {
+	switch kind {
+	case TooManyElementsMessage:
+		return "liste a " + args[0] + " entrées, maximum " + args[1]
+	case NonUniquePathsMessage:
+		return "chemins non uniques " + args[0]
+	}
+	return ""
+}
+
+func TestMessageFormatter(t *testing.T) {
+	Messages = testMessageFormatter{}
+	defer func() { Messages = englishMessages{} }()
+
+	tests := []struct {
+		name, expected, actual string
+	}{
+		{
+			name:     "formatted",
+			expected: "liste a 5 entrées, maximum 4",
+			actual: NewTooManyElementsErrorWithCount(
+				"/foo", 5, 4).GetMessage(),
+		},
+		{
+			name:     "english fallback",
+			expected: "list has 1 entries, minimum 2",
+			actual: NewTooFewElementsErrorWithCount(
+				"/foo", 1, 2).GetMessage(),
+		},
+		{
+			name:     "error string",
+			expected: "Error: /foo: chemins non uniques bar, baz",
+			actual: func() string {
+				err := NewNonUniqueError(
+					[]string{"/foo/bar", "/foo/baz"})
+				err.Path = "/foo"
+				return err.Error()
+			}(),
+		},
+	}
+	for _, test := range tests {
+		if test.actual != test.expected {
+			t.Errorf("%s: unexpected message\n  expect: %s\n  got:    %s",
+				test.name, test.expected, test.actual)
+		}
+	}
+
+	Messages = nil
+	msg := NewInstanceRequiredErrorFor("/foo", "/bar").GetMessage()
+	if !strings.HasPrefix(msg, "require-instance: /bar") {
+		t.Errorf("Unexpected message without formatter: %s", msg)
+	}
+}
+
+func TestRegisterMessageTemplate(t *testing.T) {
+	RegisterMessageTemplate("unknown-element", "{path}: unknown")
+	RegisterMessageTemplate("exec-status", "{output} ({path} {status})")
+	defer func() { messageTemplates = map[string]string{} }()
+	Messages = testMessageFormatter{}
+	defer func() { Messages = englishMessages{} }()
+
+	err := NewUnknownElementApplicationError("bar")
+	err.Path = "/foo"
+	if msg := err.GetMessage(); msg != "foo [bar]: unknown" {
+		t.Errorf("Unexpected unknown element message: %s", msg)
+	}
+	msg := NewExecErrorWithStatus([]string{"usr", "bin", "app"},
+		"core dumped", 139).GetMessage()
+	if msg != "core dumped (/usr/bin/app 139)" {
+		t.Errorf("Unexpected exec message: %s", msg)
+	}
+	// Not registered, so formatted by Messages
+	msg = NewTooManyElementsErrorWithCount("/foo", 5, 4).GetMessage()
+	if msg != "liste a 5 entrées, maximum 4" {
+		t.Errorf("Unexpected formatted message: %s", msg)
+	}
+}
+
+func TestMessageTemplatesComplete(t *testing.T) {
+	for kind := range englishMessageTemplates {
+		if _, ok := messageKinds[kind]; !ok {
+			t.Errorf("Message kind %d has no name", kind)
+		}
+	}
+	for kind := range messageKinds {
+		template, ok := englishMessageTemplates[kind]
+		if !ok {
+			t.Errorf("Message kind %s has no template", kind)
+			continue
+		}
+		for _, arg := range messageKinds[kind].args {
+			if !strings.Contains(template, "{"+arg+"}") {
+				t.Errorf("Template for %s does not use {%s}",
+					kind, arg)
+			}
+		}
+	}
+}
+
+func TestMessageDecorator(t *testing.T) {
+	SetMessageDecorator(func(e *MgmtError, msg string) string {
+		return "[" + e.Tag + "] " + msg + " (see support)"
+	})
+	defer SetMessageDecorator(nil)
+
+	plain := NewOperationFailedApplicationError().WithMessage("boom")
+	plain.Path = "/foo"
+	tooMany := NewTooManyElementsErrorWithCount("/foo", 5, 4)
+
+	tests := []struct {
+		name, expected, actual string
+	}{
+		{
+			name:     "message",
+			expected: "[operation-failed] boom (see support)",
+			actual:   plain.GetMessage(),
+		},
+		{
+			name:     "error string",
+			expected: "Error: /foo: [operation-failed] boom (see support)",
+			actual:   plain.Error(),
+		},
+		{
+			name: "composed message",
+			expected: "[operation-failed] list has 5 entries, " +
+				"maximum 4 (see support)",
+			actual: tooMany.GetMessage(),
+		},
+		{
+			name: "composed error string",
+			expected: "Error: /foo: [operation-failed] Non-unique paths " +
+				"bar, baz (see support)",
+			actual: func() string {
+				err := NewNonUniqueError(
+					[]string{"/foo/bar", "/foo/baz"})
+				err.Path = "/foo"
+				return err.Error()
+			}(),
+		},
+		{
+			name: "grouped message",
+			expected: "[operation-failed] boom; list has 5 entries, " +
+				"maximum 4 (see support)",
+			actual: func() string {
+				var errs MgmtErrorList
+				errs.MgmtErrorListAppend(plain, tooMany)
+				grouped := errs.GroupByPath().Errors()[0]
+				return grouped.(Formattable).GetMessage()
+			}(),
+		},
+		{
+			name:     "decoded grouped message",
+			expected: "boom; list has 5 entries, maximum 4",
+			actual: func() string {
+				var errs MgmtErrorList
+				errs.MgmtErrorListAppend(plain, tooMany)
+				encoded, err := json.Marshal(errs.GroupByPath())
+				if err != nil {
+					return err.Error()
+				}
+				var decoded MgmtErrorList
+				if err := json.Unmarshal(encoded, &decoded); err != nil {
+					return err.Error()
+				}
+				me, _ := AsMgmtError(decoded.Errors()[0].(Formattable))
+				return me.Message
+			}(),
+		},
+	}
+	for _, test := range tests {
+		if test.actual != test.expected {
+			t.Errorf("%s: unexpected message\n  expect: %s\n  got:    %s",
+				test.name, test.expected, test.actual)
+		}
+	}
+
+	SetMessageDecorator(nil)
+	if msg := plain.GetMessage(); msg != "boom" {
+		t.Errorf("Message decorated without decorator: %s", msg)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 )
 
@@ -86,21 +87,100 @@ func (t errtype) String() string {
 type MgmtErrorInfoTag struct {
 	XMLName xml.Name
 	Value   string `xml:",chardata"`
+
+	// Attrs holds any XML attributes of the element, other than
+	// namespace declarations. A slice, rather than a map keyed by name,
+	// keeps their order and namespaces. They are carried by the XML and
+	// gob encodings, but not by the JSON encoding, which has no place
+	// for them; they are lost if the error is passed through JSON.
+	Attrs []xml.Attr `xml:"-" json:"-"`
+}
+
+func (i MgmtErrorInfoTag) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	start.Name = i.XMLName
+	// Inherit the NETCONF base namespace of the enclosing rpc-error
+	if start.Name.Space == netconf_namespace {
+		start.Name.Space = ""
+	}
+	start.Attr = i.Attrs
+	return enc.EncodeElement(i.Value, start)
+}
+
+func (i *MgmtErrorInfoTag) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var elem struct {
+		Value string `xml:",chardata"`
+	}
+	if err := dec.DecodeElement(&elem, &start); err != nil {
+		return err
+	}
+	i.XMLName = start.Name
+	i.Value = elem.Value
+	i.Attrs = nil
+	for _, attr := range start.Attr {
+		// Skip namespace declarations
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		i.Attrs = append(i.Attrs, attr)
+	}
+	return nil
 }
 
 // RFC7951 section 4 dictates that module name instead of namespace is
 // used to differentiate elements when JSON encoded.
 //
-// Since we only have 3 namespaces to worry about right now, we can do
-// the mapping locally. In the future, yangd can get a method to
-// lookup namespaces/modules.
-func (i *MgmtErrorInfoTag) lookupNamespace(module string) string {
-	modmap := map[string]string{
+// The NETCONF, YANG and Vyatta namespaces are known locally, others
+// may be added with RegisterInfoNamespace. In the future, yangd can get
+// a method to lookup namespaces/modules.
+var (
+	infoModuleToNamespace = map[string]string{
 		netconf_module: netconf_namespace,
 		yang_module:    yang_namespace,
 		vyattaModule:   VyattaNamespace,
 	}
-	ns, ok := modmap[module]
+	infoNamespaceToModule = map[string]string{
+		netconf_namespace: netconf_module,
+		yang_namespace:    yang_module,
+		VyattaNamespace:   vyattaModule,
+	}
+)
+
+// RegisterInfoNamespace adds a module to namespace mapping used when
+// encoding and decoding error-info tags. The netconf, yang and vyatta
+// modules are registered by default.
+func RegisterInfoNamespace(module, namespace string) {
+	infoModuleToNamespace[module] = namespace
+	infoNamespaceToModule[namespace] = module
+}
+
+// CanonicalInfoNamespace returns the namespace, and the module name if
+// registered, for an error-info tag in namespace ns.
+//
+// A tag without a namespace inherits the NETCONF base namespace of the
+// enclosing rpc-error. ns may also be given as a registered module name.
+func CanonicalInfoNamespace(ns string) (namespace, module string) {
+	if ns == "" {
+		ns = netconf_namespace
+	}
+	if n, ok := infoModuleToNamespace[ns]; ok {
+		ns = n
+	}
+	return ns, infoNamespaceToModule[ns]
+}
+
+// sameInfoNamespace reports whether a and b are the same namespace once
+// made canonical, eg "" and the NETCONF base namespace.
+func sameInfoNamespace(a, b string) bool {
+	if a == b {
+		return true
+	}
+	a, _ = CanonicalInfoNamespace(a)
+	b, _ = CanonicalInfoNamespace(b)
+	return a == b
+}
+
+func (i *MgmtErrorInfoTag) lookupNamespace(module string) string {
+	ns, ok := infoModuleToNamespace[module]
 	if !ok {
 		return module
 	}
@@ -108,12 +188,7 @@ func (i *MgmtErrorInfoTag) lookupNamespace(module string) string {
 }
 
 func (i *MgmtErrorInfoTag) lookupModule(ns string) string {
-	nsmap := map[string]string{
-		netconf_namespace: netconf_module,
-		yang_namespace:    yang_module,
-		VyattaNamespace:   vyattaModule,
-	}
-	module, ok := nsmap[ns]
+	module, ok := infoNamespaceToModule[ns]
 	if !ok {
 		return ns
 	}
@@ -129,12 +204,16 @@ func (i *MgmtErrorInfoTag) UnmarshalJSON(value []byte) error {
 		return errors.New("malformed error-info tag")
 	}
 	for k, v := range obj {
-		s := strings.Split(k, ":")
-		if len(s) == 2 {
-			i.XMLName.Space = i.lookupNamespace(s[0])
-			i.XMLName.Local = s[1]
+		// An element name cannot contain ":", so the module is
+		// everything before the last one. This allows the namespace
+		// of an unregistered module, eg "urn:foo:bar", to be used
+		// in its place.
+		if sep := strings.LastIndex(k, ":"); sep >= 0 {
+			i.XMLName.Space = i.lookupNamespace(k[:sep])
+			i.XMLName.Local = k[sep+1:]
 		} else {
-			i.XMLName.Local = s[0]
+			i.XMLName.Space = netconf_namespace
+			i.XMLName.Local = k
 		}
 		i.Value = v
 	}
@@ -145,7 +224,7 @@ func (i *MgmtErrorInfoTag) MarshalJSON() ([]byte, error) {
 	var tag string
 	var out bytes.Buffer
 	out.WriteString("{")
-	if len(i.XMLName.Space) > 0 {
+	if !sameInfoNamespace(i.XMLName.Space, netconf_namespace) {
 		tag = i.lookupModule(i.XMLName.Space) + ":" + i.XMLName.Local
 	} else {
 		tag = i.XMLName.Local
@@ -165,7 +244,18 @@ func (i *MgmtErrorInfoTag) MarshalJSON() ([]byte, error) {
 	return out.Bytes(), nil
 }
 
+// NewMgmtErrorInfoTag creates an info tag. The name must not be empty,
+// as the tag could not then be encoded; this is checked by Validate, or
+// on construction when PanicOnInvalidInfoTag is set.
+//
+// The namespace is made canonical, as by CanonicalInfoNamespace, so an
+// empty ns gives the NETCONF base namespace, and a registered module
+// name gives its namespace.
 func NewMgmtErrorInfoTag(ns, name, value string) *MgmtErrorInfoTag {
+	if name == "" && PanicOnInvalidInfoTag {
+		panic(errors.New("error-info tag has no name"))
+	}
+	ns, _ = CanonicalInfoNamespace(ns)
 	return &MgmtErrorInfoTag{
 		XMLName: xml.Name{
 			Space: ns,
@@ -175,6 +265,20 @@ func NewMgmtErrorInfoTag(ns, name, value string) *MgmtErrorInfoTag {
 	}
 }
 
+// NewMgmtErrorInfoTagByModule creates an info tag in the namespace of
+// the given module. The module must be one of the known modules, or
+// have been added with RegisterInfoNamespace.
+func NewMgmtErrorInfoTagByModule(module, name, value string) (*MgmtErrorInfoTag, error) {
+	ns, ok := infoModuleToNamespace[module]
+	if !ok {
+		return nil, fmt.Errorf("unknown error-info module: %s", module)
+	}
+	if name == "" {
+		return nil, errors.New("error-info tag has no name")
+	}
+	return NewMgmtErrorInfoTag(ns, name, value), nil
+}
+
 type MgmtErrorInfo []MgmtErrorInfoTag
 
 func (e *MgmtErrorInfo) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
@@ -196,6 +300,10 @@ func (e *MgmtErrorInfo) MarshalXML(enc *xml.Encoder, start xml.StartElement) err
 	return nil
 }
 
+// UnmarshalXML decodes the info tags, matching elements on their
+// resolved namespace rather than the prefix used by the peer. Tags
+// without a namespace are in the NETCONF base namespace, as they are
+// when constructed.
 func (e *MgmtErrorInfo) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
 	var infos []MgmtErrorInfoTag
 Loop:
@@ -210,6 +318,8 @@ Loop:
 			if err := dec.DecodeElement(&i, &elem); err != nil {
 				return err
 			}
+			i.XMLName.Space, _ = CanonicalInfoNamespace(
+				i.XMLName.Space)
 			infos = append(infos, i)
 		case xml.EndElement:
 			break Loop
@@ -219,9 +329,64 @@ Loop:
 	return nil
 }
 
+// MarshalNestedJSON encodes the info tags as an object keyed by
+// namespace, each holding an object mapping a tag name to the list of
+// its values, eg:
+//
+//	{"urn:ietf:params:xml:ns:yang:1": {"non-unique": ["/a/b", "/a/c"]}}
+//
+// Tags in the NETCONF base namespace are keyed by "". The order of values for a
+// tag is preserved, but not the order of different tags.
+func (e MgmtErrorInfo) MarshalNestedJSON() ([]byte, error) {
+	nested := make(map[string]map[string][]string)
+	for _, t := range e {
+		ns := t.XMLName.Space
+		if sameInfoNamespace(ns, netconf_namespace) {
+			ns = ""
+		}
+		names, ok := nested[ns]
+		if !ok {
+			names = make(map[string][]string)
+			nested[ns] = names
+		}
+		names[t.XMLName.Local] = append(names[t.XMLName.Local], t.Value)
+	}
+	return json.Marshal(nested)
+}
+
+// UnmarshalNestedJSON decodes info tags encoded by MarshalNestedJSON.
+// Tags are ordered by namespace and then by name.
+func (e *MgmtErrorInfo) UnmarshalNestedJSON(value []byte) error {
+	var nested map[string]map[string][]string
+	if err := json.Unmarshal(value, &nested); err != nil {
+		return err
+	}
+	spaces := make([]string, 0, len(nested))
+	for ns := range nested {
+		spaces = append(spaces, ns)
+	}
+	sort.Strings(spaces)
+
+	info := MgmtErrorInfo{}
+	for _, ns := range spaces {
+		names := make([]string, 0, len(nested[ns]))
+		for name := range nested[ns] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			for _, v := range nested[ns][name] {
+				info = append(info, *NewMgmtErrorInfoTag(ns, name, v))
+			}
+		}
+	}
+	*e = info
+	return nil
+}
+
 func (e MgmtErrorInfo) FindMgmtErrorTag(ns, name string) string {
 	for _, t := range e {
-		if t.XMLName.Space == ns && t.XMLName.Local == name {
+		if t.XMLName.Local == name && sameInfoNamespace(t.XMLName.Space, ns) {
 			return t.Value
 		}
 	}
@@ -279,6 +444,86 @@ type MgmtError struct {
 	// extended and/or implementation- specific debugging
 	// information.
 	Info MgmtErrorInfo `xml:"error-info,omitempty" json:"error-info,omitempty"`
+
+	// noPath records that the error intentionally has no Path
+	noPath bool
+
+	// emptyAppTag and emptyPath record that AppTag and Path are
+	// present, but empty, either as decoded or as set by WithAppTag("")
+	// or WithPath(""). They are compared by reflect.DeepEqual, so such
+	// an error is not deeply equal to one without the element, although
+	// the two encode the same; use Equal to compare content.
+	emptyAppTag bool
+	emptyPath   bool
+
+	// cause is the underlying error, see WithCause. It is not encoded.
+	cause error
+}
+
+// mgmtErrorFields has the fields of MgmtError, but not its decode
+// methods.
+type mgmtErrorFields MgmtError
+
+func (e *MgmtError) UnmarshalJSON(value []byte) error {
+	if err := json.Unmarshal(value, (*mgmtErrorFields)(e)); err != nil {
+		return err
+	}
+	var present struct {
+		AppTag *string `json:"error-app-tag"`
+		Path   *string `json:"error-path"`
+	}
+	if err := json.Unmarshal(value, &present); err != nil {
+		return err
+	}
+	e.emptyAppTag = present.AppTag != nil && *present.AppTag == ""
+	e.emptyPath = present.Path != nil && *present.Path == ""
+	return nil
+}
+
+func (e *MgmtError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var fields struct {
+		Typ      string        `xml:"error-type"`
+		Tag      string        `xml:"error-tag"`
+		Severity string        `xml:"error-severity"`
+		AppTag   *string       `xml:"error-app-tag"`
+		Path     *string       `xml:"error-path"`
+		Message  string        `xml:"error-message"`
+		Info     MgmtErrorInfo `xml:"error-info"`
+	}
+	if err := dec.DecodeElement(&fields, &start); err != nil {
+		return err
+	}
+	// Fields are matched on local name, so any prefix may be used for
+	// the NETCONF namespace; the canonical name is kept for marshalling.
+	e.setXMLName()
+	e.Typ = fields.Typ
+	e.Tag = fields.Tag
+	e.Severity = fields.Severity
+	e.AppTag, e.emptyAppTag = "", false
+	if fields.AppTag != nil {
+		e.AppTag = *fields.AppTag
+		e.emptyAppTag = e.AppTag == ""
+	}
+	e.Path, e.emptyPath = "", false
+	if fields.Path != nil {
+		e.Path = *fields.Path
+		e.emptyPath = e.Path == ""
+	}
+	e.Message = fields.Message
+	e.Info = fields.Info
+	return nil
+}
+
+// LookupAppTag returns the error-app-tag and whether it is present,
+// distinguishing an empty app-tag from an absent one.
+func (e *MgmtError) LookupAppTag() (string, bool) {
+	return e.AppTag, e.AppTag != "" || e.emptyAppTag
+}
+
+// LookupPath returns the error-path and whether it is present,
+// distinguishing an empty path from an absent one.
+func (e *MgmtError) LookupPath() (string, bool) {
+	return e.Path, e.Path != "" || e.emptyPath
 }
 
 func newMgmtError() *MgmtError {
@@ -293,12 +538,59 @@ func newMgmtError() *MgmtError {
 // includes a MgmtError object, in which case that's ok.
 type MgmtErrorRef interface {
 	mgmtErrorRef()
+	getMgmtError() *MgmtError
 }
 
 var _ MgmtErrorRef = (*MgmtError)(nil)
 
 func (me *MgmtError) mgmtErrorRef() {}
 
+func (me *MgmtError) getMgmtError() *MgmtError { return me }
+
+// errorOrigin returns the namespace of the table that defines err,
+// searched in the same order as when unmarshalling an error list.
+// NETCONF errors are the most generic (don't use app-tag) so are
+// searched last.
+func errorOrigin(err error) string {
+	ref, ok := err.(MgmtErrorRef)
+	if !ok {
+		return ""
+	}
+	me := ref.getMgmtError()
+	if me == nil {
+		return ""
+	}
+	if _, ok := vyattaErrorCreator(me); ok {
+		return VyattaNamespace
+	}
+	if _, ok := yangErrorCreator(me); ok {
+		return yang_namespace
+	}
+	if _, ok := netconfErrorCreator(me); ok {
+		return netconf_namespace
+	}
+	return ""
+}
+
+// IsNetconfError reports whether err is one of the NETCONF errors
+// defined by RFC6241 Appendix A, and not a more specific YANG or Vyatta
+// error.
+func IsNetconfError(err error) bool {
+	return errorOrigin(err) == netconf_namespace
+}
+
+// IsYangError reports whether err is one of the YANG errors defined by
+// RFC6020 Section 13.
+func IsYangError(err error) bool {
+	return errorOrigin(err) == yang_namespace
+}
+
+// IsVyattaError reports whether err is one of the Vyatta specific
+// errors.
+func IsVyattaError(err error) bool {
+	return errorOrigin(err) == VyattaNamespace
+}
+
 // Formattable - interface provided by error types to allow formatting
 // (NB: MgmtError is just one example of such a type.)
 //
@@ -320,14 +612,54 @@ type Formattable interface {
 // Ensure *MgmtError implements interface
 var _ Formattable = (*MgmtError)(nil)
 
-func (me *MgmtError) GetMessage() string     { return me.Message }
 func (me *MgmtError) GetPath() string        { return me.Path }
-func (me *MgmtError) GetSeverity() string    { return me.Severity }
 func (me *MgmtError) GetTag() string         { return me.Tag }
 func (me *MgmtError) GetAppTag() string      { return me.AppTag }
 func (me *MgmtError) GetType() string        { return me.Typ }
 func (me *MgmtError) GetInfo() MgmtErrorInfo { return me.Info }
 
+// GetMessage returns the message, decorated by the function set with
+// SetMessageDecorator, if any. The specific error types may compose the
+// message from the content of the error.
+func (me *MgmtError) GetMessage() string {
+	return me.decorate(me.message())
+}
+
+// message returns the message before it is decorated. The specific
+// error types which compose their message override it.
+func (me *MgmtError) message() string { return me.Message }
+
+// undecoratedMessage returns the message of f without the decoration
+// added by GetMessage, if it is an error of this package, so that only
+// the raw message is stored when it is copied into another error.
+func undecoratedMessage(f Formattable) string {
+	if m, ok := f.(interface{ message() string }); ok {
+		return m.message()
+	}
+	return f.GetMessage()
+}
+
+// GetSeverity returns the error-severity, which is "error" if none is
+// set, eg for an error decoded from a peer that omits it.
+func (me *MgmtError) GetSeverity() string {
+	if me.Severity == "" {
+		return nc_severity_error.String()
+	}
+	return me.Severity
+}
+
+// AsMgmtError returns the MgmtError underlying f, which may be a
+// *MgmtError or one of the types wrapping it, so that its fields can be
+// modified.
+func AsMgmtError(f Formattable) (*MgmtError, bool) {
+	ref, ok := f.(MgmtErrorRef)
+	if !ok {
+		return nil, false
+	}
+	me := ref.getMgmtError()
+	return me, me != nil
+}
+
 func callCreate(fn interface{}, err *MgmtError) error {
 	ty := reflect.TypeOf(fn)
 	if ty.Kind() != reflect.Func ||
@@ -354,10 +686,359 @@ func (e *MgmtError) setXMLName() {
 	}
 }
 
+// CanonicalJSON encodes the error as JSON which is identical for equal
+// errors, so that it can be hashed, eg for caching or deduplication.
+// Unlike MarshalJSON, the info tags are sorted by namespace, name and
+// value, as their order is not significant.
+func (e *MgmtError) CanonicalJSON() ([]byte, error) {
+	c := *e
+	c.Info = append(MgmtErrorInfo(nil), e.Info...)
+	sort.SliceStable(c.Info, func(i, j int) bool {
+		a, b := c.Info[i], c.Info[j]
+		if a.XMLName.Space != b.XMLName.Space {
+			return a.XMLName.Space < b.XMLName.Space
+		}
+		if a.XMLName.Local != b.XMLName.Local {
+			return a.XMLName.Local < b.XMLName.Local
+		}
+		return a.Value < b.Value
+	})
+	return json.Marshal(&c)
+}
+
+// InfoString renders the error-info as an indented block, one
+// "name: value" line per tag sorted by name and value, eg for verbose
+// CLI output. Namespaces are omitted. It is empty if there is no info.
+func (e *MgmtError) InfoString() string {
+	info := append(MgmtErrorInfo(nil), e.Info...)
+	sort.SliceStable(info, func(i, j int) bool {
+		a, b := info[i], info[j]
+		if a.XMLName.Local != b.XMLName.Local {
+			return a.XMLName.Local < b.XMLName.Local
+		}
+		return a.Value < b.Value
+	})
+	var b strings.Builder
+	for i, t := range info {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString("  ")
+		b.WriteString(t.XMLName.Local)
+		b.WriteString(": ")
+		b.WriteString(t.Value)
+	}
+	return b.String()
+}
+
+// WithAppTag sets the data-model-specific or implementation-specific
+// error-app-tag of the error, eg on one of the NETCONF errors whose
+// constructors do not take one.
+func (e *MgmtError) WithAppTag(tag string) *MgmtError {
+	e.AppTag = tag
+	e.emptyAppTag = tag == ""
+	return e
+}
+
+// WithMessage sets the error-message, replacing the default message of
+// the error type.
+func (e *MgmtError) WithMessage(msg string) *MgmtError {
+	e.Message = msg
+	return e
+}
+
+// WithCause records the underlying error that led to the error, eg one
+// returned by the OS, so that it can be found by errors.Is and
+// errors.As. The cause is only kept locally; it is not encoded.
+//
+// A cause which wraps the error, directly or indirectly, is not
+// recorded, as errors.Is and errors.As would then never return. Any
+// cause already recorded is kept instead.
+func (e *MgmtError) WithCause(cause error) *MgmtError {
+	if !e.wraps(cause, make(map[interface{}]bool), 0) {
+		e.cause = cause
+	}
+	return e
+}
+
+// wraps reports whether err is the error, or wraps it, following the
+// same Unwrap methods as errors.Is. Each error is visited once, and one
+// which cannot be identified only to a depth of maxUnwrapDepth, so that
+// a cycle among errors not created by WithCause cannot recurse forever.
+func (e *MgmtError) wraps(
+	err error,
+	visited map[interface{}]bool,
+	depth int,
+) bool {
+	for ; err != nil; depth++ {
+		if ref, ok := err.(MgmtErrorRef); ok && ref.getMgmtError() == e {
+			return true
+		}
+		if key, ok := errorKey(err); ok {
+			if visited[key] {
+				return false
+			}
+			visited[key] = true
+		} else if depth >= maxUnwrapDepth {
+			return false
+		}
+		switch wrapper := err.(type) {
+		case interface{ Unwrap() error }:
+			err = wrapper.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, err := range wrapper.Unwrap() {
+				if e.wraps(err, visited, depth+1) {
+					return true
+				}
+			}
+			return false
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// Unwrap returns the cause recorded by WithCause, if any.
+func (e *MgmtError) Unwrap() error {
+	return e.cause
+}
+
+// WithPath sets the error-path, replacing any existing path.
+func (e *MgmtError) WithPath(path string) *MgmtError {
+	e.Path = path
+	e.emptyPath = path == ""
+	e.noPath = false
+	return e
+}
+
+// SanitizeUTF8 replaces any invalid UTF-8 in the error's strings, eg
+// from the output of an external command run in a non-UTF-8 locale,
+// with the Unicode replacement character U+FFFD. While the encoders
+// also do so, the string returned by Error() would otherwise contain
+// the invalid bytes.
+func (e *MgmtError) SanitizeUTF8() *MgmtError {
+	const replacement = "\uFFFD"
+	e.Typ = strings.ToValidUTF8(e.Typ, replacement)
+	e.Tag = strings.ToValidUTF8(e.Tag, replacement)
+	e.Severity = strings.ToValidUTF8(e.Severity, replacement)
+	e.AppTag = strings.ToValidUTF8(e.AppTag, replacement)
+	e.Path = strings.ToValidUTF8(e.Path, replacement)
+	e.Message = strings.ToValidUTF8(e.Message, replacement)
+	for i := range e.Info {
+		e.Info[i].Value = strings.ToValidUTF8(e.Info[i].Value,
+			replacement)
+	}
+	return e
+}
+
+// PrependPath adds segment, one or more path elements, to the start of
+// the error's Path, eg to add the context of the list entry being
+// validated as the error is returned up through the schema. The result
+// always has a single leading "/" and a single "/" between segment and
+// the existing path.
+func (e *MgmtError) PrependPath(segment string) *MgmtError {
+	segment = strings.Trim(segment, "/")
+	if segment == "" {
+		return e
+	}
+	path := strings.TrimPrefix(e.Path, "/")
+	if path == "" {
+		e.Path = "/" + segment
+	} else {
+		e.Path = "/" + segment + "/" + path
+	}
+	e.noPath = false
+	return e
+}
+
+// MergeInfo adds the error-info tags of other to those of the error,
+// eg when combining duplicate errors that carry different details. Tags
+// are matched by namespace and name: a tag already present with the
+// same value is not repeated, and a non-empty value is preferred to an
+// empty one. Tags with the same name but different values, such as the
+// paths of a NonUniqueError, are all kept.
+func (e *MgmtError) MergeInfo(other *MgmtError) *MgmtError {
+	if other == nil {
+		return e
+	}
+	for _, t := range other.Info {
+		e.mergeInfoTag(t)
+	}
+	return e
+}
+
+func (e *MgmtError) mergeInfoTag(tag MgmtErrorInfoTag) {
+	for i, t := range e.Info {
+		if t.XMLName != tag.XMLName {
+			continue
+		}
+		if t.Value == tag.Value || tag.Value == "" {
+			return
+		}
+		if t.Value == "" {
+			e.Info[i] = tag
+			return
+		}
+	}
+	e.Info = append(e.Info, tag)
+}
+
+// isPathInfoTag reports whether the value of an error-info tag is a
+// path to data, which must be rebased along with the error's Path.
+func isPathInfoTag(t MgmtErrorInfoTag) bool {
+	switch t.XMLName.Space {
+	case yang_namespace:
+		return t.XMLName.Local == non_unique_info.String() ||
+			t.XMLName.Local == required_instance_info.String()
+	case VyattaNamespace:
+		return t.XMLName.Local == related_path_info.String()
+	}
+	return false
+}
+
+// rebase replaces oldPrefix, which has no trailing "/", at the start of
+// path with newPrefix. Only whole path elements are matched.
+func rebase(path, oldPrefix, newPrefix string) (string, bool) {
+	if path != oldPrefix && !strings.HasPrefix(path, oldPrefix+"/") {
+		return path, false
+	}
+	rest := strings.TrimPrefix(path, oldPrefix)
+	if newPrefix == "" && rest == "" {
+		return "/", true
+	}
+	return newPrefix + rest, true
+}
+
+// RebasePath moves the error from under oldPrefix to under newPrefix,
+// eg when a module's schema is mounted under a different parent. Both
+// Path and the paths held in error-info, such as those of a
+// NonUniqueError, are rewritten; info paths outside oldPrefix are left
+// as they are.
+//
+// An error is returned, and the error left unchanged, if Path is not
+// oldPrefix or below it.
+func (e *MgmtError) RebasePath(oldPrefix, newPrefix string) error {
+	oldPrefix = strings.TrimRight(oldPrefix, "/")
+	newPrefix = strings.TrimRight(newPrefix, "/")
+	path, ok := rebase(e.Path, oldPrefix, newPrefix)
+	if !ok {
+		return fmt.Errorf("path %s is not under %s", e.Path, oldPrefix)
+	}
+	e.Path = path
+	for i, t := range e.Info {
+		if isPathInfoTag(t) {
+			e.Info[i].Value, _ = rebase(t.Value, oldPrefix, newPrefix)
+		}
+	}
+	return nil
+}
+
+// RelativeTo returns a copy of the error with Path, and the paths held
+// in error-info, shown relative to base, eg for display below a path
+// that has already been shown. Paths which are not below base, and all
+// paths if base is empty, are kept in full. The error itself is not
+// changed.
+//
+// NonUniqueError, InstanceRequiredError and LeafrefMismatchError have
+// their own RelativeTo returning a copy of their type, so that the
+// messages composed from their paths are relative too.
+func (e *MgmtError) RelativeTo(base string) *MgmtError {
+	c := *e
+	c.Path = relativePath(base, e.Path)
+	c.Info = append(MgmtErrorInfo(nil), e.Info...)
+	for i, t := range c.Info {
+		if isPathInfoTag(t) {
+			c.Info[i].Value = relativePath(base, t.Value)
+		}
+	}
+	return &c
+}
+
+func relativePath(base, path string) string {
+	base = strings.TrimRight(base, "/")
+	if base == "" || !strings.HasPrefix(path, base+"/") {
+		return path
+	}
+	return strings.TrimPrefix(path, base+"/")
+}
+
+// PathWithPrefixes returns Path with each node name qualified by its
+// module prefix, eg /if:interfaces/if:interface, as a strict NETCONF
+// client expects of an error-path. prefixMap maps node names to
+// prefixes; names not in it, and names that already have a prefix, are
+// left as they are. Any predicate following a name is kept.
+//
+// The prefixes must be bound in the namespace context of the message
+// carrying the error.
+func (e *MgmtError) PathWithPrefixes(prefixMap map[string]string) string {
+	if e.Path == "" || len(prefixMap) == 0 {
+		return e.Path
+	}
+	segs := strings.Split(e.Path, "/")
+	for i, seg := range segs {
+		name := seg
+		if j := strings.IndexByte(seg, '['); j >= 0 {
+			name = seg[:j]
+		}
+		if name == "" || strings.Contains(name, ":") {
+			continue
+		}
+		if prefix, ok := prefixMap[name]; ok && prefix != "" {
+			segs[i] = prefix + ":" + seg
+		}
+	}
+	return strings.Join(segs, "/")
+}
+
+// ErrorPathPrefixes, if set, maps node names to module prefixes with
+// which to qualify the error-path of each error when a MgmtErrorList is
+// encoded as XML, eg in an rpc-reply, as by PathWithPrefixes. It is nil
+// by default, leaving the error-path unchanged. The prefixes can be
+// applied to a single error with PathWithPrefixes instead.
+var ErrorPathPrefixes map[string]string
+
+var messageDecorator func(*MgmtError, string) string
+
+// SetMessageDecorator sets a function to decorate the message of each
+// error, eg with a product banner or a support URL, as returned by
+// GetMessage and included in Error. The function is given the error and
+// its fully composed message, and returns the message to use. It is nil
+// by default, leaving the message unchanged. The message stored in the
+// error, and so encoded, is not decorated.
+func SetMessageDecorator(fn func(*MgmtError, string) string) {
+	messageDecorator = fn
+}
+
+func (e *MgmtError) decorate(msg string) string {
+	if messageDecorator == nil {
+		return msg
+	}
+	return messageDecorator(e, msg)
+}
+
+// The known severities as they start an error string, avoiding
+// strings.Title on each call of Error().
+var titledSeverities = map[string]string{
+	nc_severity_error.String():   "Error",
+	nc_severity_warning.String(): "Warning",
+}
+
+func titledSeverity(severity string) string {
+	if t, ok := titledSeverities[severity]; ok {
+		return t
+	}
+	return strings.Title(severity)
+}
+
 func (e MgmtError) Error() string {
-	var b bytes.Buffer
+	var b strings.Builder
 
-	b.WriteString(strings.Title(e.Severity))
+	severity := titledSeverity(e.GetSeverity())
+	msg := e.decorate(e.Message)
+	b.Grow(len(severity) + len(e.Path) + len(msg) +
+		2*len(error_msg_separator))
+	b.WriteString(severity)
 	b.WriteString(error_msg_separator)
 
 	if e.Path != "" {
@@ -365,8 +1046,8 @@ func (e MgmtError) Error() string {
 		b.WriteString(error_msg_separator)
 	}
 
-	if e.Message != "" {
-		b.WriteString(e.Message)
+	if msg != "" {
+		b.WriteString(msg)
 	}
 
 	return b.String()
@@ -374,9 +1055,33 @@ func (e MgmtError) Error() string {
 
 const errpfx = "com.vyatta.rpcerror."
 
+// DBusErrorNameByTag makes DBusError name errors by their error-tag,
+// eg com.vyatta.rpcerror.in_use, rather than their error-type, giving
+// a name that does not vary with the layer reporting the error. It is
+// false by default. DBusError has the fixed signature through which
+// errors are sent over DBus, so the naming cannot be chosen per call.
+var DBusErrorNameByTag bool
+
+// dbusErrorName returns the DBus error name for the error. An error
+// without a valid error-type is named as an application error.
+func (e *MgmtError) dbusErrorName() string {
+	if DBusErrorNameByTag {
+		tag := e.Tag
+		if _, ok := ncerrtagmap[tag]; !ok {
+			tag = operation_failed.String()
+		}
+		// Hyphens are not allowed in DBus names
+		return errpfx + strings.Replace(tag, "-", "_", -1)
+	}
+	if _, ok := errtypemap[e.Typ]; !ok {
+		return errpfx + application.String()
+	}
+	return errpfx + e.Typ
+}
+
 // Encode error for DBus
 func (e *MgmtError) DBusError() (string, []interface{}) {
-	name := fmt.Sprintf("%s%s", errpfx, e.Typ)
+	name := e.dbusErrorName()
 	body := make([]interface{}, 1)
 	body[0] = e
 	return name, body
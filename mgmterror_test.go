@@ -10,8 +10,12 @@ package mgmterror
 import (
 	"encoding/json"
 	"encoding/xml"
+	"errors"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func cmpMgmtError(t *testing.T, exp, unmarshal *MgmtError) {
@@ -71,3 +75,735 @@ func TestMgmtErrorConstruction(t *testing.T) {
 	}
 	verifyMgmtErrorConstruction(t, exp, newMgmtError())
 }
+
+func TestCanonicalInfoNamespace(t *testing.T) {
+	tests := []struct {
+		ns, expNs, expModule string
+	}{
+		{"", netconf_namespace, netconf_module},
+		{netconf_namespace, netconf_namespace, netconf_module},
+		{yang_module, yang_namespace, yang_module},
+		{VyattaNamespace, VyattaNamespace, vyattaModule},
+		{"urn:unknown", "urn:unknown", ""},
+	}
+	for _, test := range tests {
+		ns, module := CanonicalInfoNamespace(test.ns)
+		if ns != test.expNs || module != test.expModule {
+			t.Errorf("CanonicalInfoNamespace(%q) = %q, %q; expect %q, %q",
+				test.ns, ns, module, test.expNs, test.expModule)
+		}
+		tag := NewMgmtErrorInfoTag(test.ns, "foo", "bar")
+		if tag.XMLName.Space != test.expNs {
+			t.Errorf("NewMgmtErrorInfoTag(%q) has namespace %q; expect %q",
+				test.ns, tag.XMLName.Space, test.expNs)
+		}
+	}
+
+	info := MgmtErrorInfo{*NewMgmtErrorInfoTag("", "bad-element", "x")}
+	if value := info.FindMgmtErrorTag("", "bad-element"); value != "x" {
+		t.Errorf("NETCONF info tag not found without namespace")
+	}
+}
+
+func TestRegisterInfoNamespace(t *testing.T) {
+	const (
+		module = "test-registered-module"
+		ns     = "urn:test:registered:1"
+	)
+	RegisterInfoNamespace(module, ns)
+	defer func() {
+		delete(infoModuleToNamespace, module)
+		delete(infoNamespaceToModule, ns)
+	}()
+
+	tag := NewMgmtErrorInfoTag(ns, "foo", "bar")
+	marshal, err := json.Marshal(tag)
+	if err != nil {
+		t.Fatalf("Marshal error: %v\n", err)
+	}
+	if exp := `{"` + module + `:foo":"bar"}`; string(marshal) != exp {
+		t.Errorf("Unexpected JSON\n  expect: %s\n  got:    %s",
+			exp, marshal)
+	}
+	var unmarshal MgmtErrorInfoTag
+	if err := json.Unmarshal(marshal, &unmarshal); err != nil {
+		t.Fatalf("Unmarshal error: %v\n", err)
+	}
+	if !reflect.DeepEqual(*tag, unmarshal) {
+		t.Errorf("Failed info tag JSON marshal/unmarshal")
+		t.Logf("Expected: %#v", *tag)
+		t.Logf("Result:   %#v", unmarshal)
+	}
+
+	if got, _ := CanonicalInfoNamespace(module); got != ns {
+		t.Errorf("Module %s resolved to %s, expect %s", module, got, ns)
+	}
+}
+
+func TestInfoTagUnregisteredNamespaceJSON(t *testing.T) {
+	tests := []struct {
+		tag  *MgmtErrorInfoTag
+		json string
+	}{
+		{
+			tag:  NewMgmtErrorInfoTag("urn:foo:bar", "baz", "1"),
+			json: `{"urn:foo:bar:baz":"1"}`,
+		},
+		{
+			tag:  NewMgmtErrorInfoTag("http://example.com/ns", "baz", "2"),
+			json: `{"http://example.com/ns:baz":"2"}`,
+		},
+		{
+			tag:  NewMgmtErrorInfoTag("unregistered-module", "baz", "3"),
+			json: `{"unregistered-module:baz":"3"}`,
+		},
+	}
+	for _, test := range tests {
+		marshal, err := json.Marshal(test.tag)
+		if err != nil {
+			t.Fatalf("Marshal error: %v\n", err)
+		}
+		if string(marshal) != test.json {
+			t.Errorf("Unexpected JSON\n  expect: %s\n  got:    %s",
+				test.json, marshal)
+		}
+		var unmarshal MgmtErrorInfoTag
+		if err := json.Unmarshal(marshal, &unmarshal); err != nil {
+			t.Fatalf("Unmarshal error: %v\n", err)
+		}
+		if !reflect.DeepEqual(*test.tag, unmarshal) {
+			t.Errorf("Failed info tag JSON marshal/unmarshal")
+			t.Logf("Expected: %#v", *test.tag)
+			t.Logf("Result:   %#v", unmarshal)
+		}
+	}
+}
+
+func TestNewMgmtErrorInfoTagByModule(t *testing.T) {
+	tag, err := NewMgmtErrorInfoTagByModule(yang_module, "non-unique", "/foo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v\n", err)
+	}
+	exp := NewMgmtErrorInfoTag(yang_namespace, "non-unique", "/foo")
+	if !reflect.DeepEqual(exp, tag) {
+		t.Errorf("Unexpected info tag")
+		t.Logf("Expected: %#v", exp)
+		t.Logf("Result:   %#v", tag)
+	}
+
+	if _, err := NewMgmtErrorInfoTagByModule("no-such-module", "foo", "bar"); err == nil {
+		t.Errorf("Expected error for unregistered module")
+	}
+}
+
+func TestErrorClassification(t *testing.T) {
+	tests := []struct {
+		name                  string
+		err                   error
+		netconf, yang, vyatta bool
+	}{
+		{"in-use", NewInUseProtocolError(), true, false, false},
+		{"operation-failed", NewOperationFailedApplicationError(),
+			true, false, false},
+		{"must-violation", NewMustViolationError(), false, true, false},
+		{"missing-instance", NewInsertFailedError(), false, true, false},
+		{"exec-failed", NewExecError([]string{"foo"}, "out"),
+			false, false, true},
+		{"plain MgmtError", newMgmtError(), false, false, false},
+		{"not a MgmtError", errors.New("plain"), false, false, false},
+		{"nil", nil, false, false, false},
+	}
+	for _, test := range tests {
+		if got := IsNetconfError(test.err); got != test.netconf {
+			t.Errorf("%s: IsNetconfError = %v", test.name, got)
+		}
+		if got := IsYangError(test.err); got != test.yang {
+			t.Errorf("%s: IsYangError = %v", test.name, got)
+		}
+		if got := IsVyattaError(test.err); got != test.vyatta {
+			t.Errorf("%s: IsVyattaError = %v", test.name, got)
+		}
+	}
+}
+
+func TestMgmtErrorInfoTagAttrs(t *testing.T) {
+	const rpcErr = `<rpc-error xmlns="` + netconf_namespace + `">
+	<error-type>application</error-type>
+	<error-tag>too-big</error-tag>
+	<error-severity>error</error-severity>
+	<error-info>
+		<limit xmlns="` + VyattaNamespace + `" scope="session" unit="bytes">1024</limit>
+		<bad-element>config</bad-element>
+	</error-info>
+</rpc-error>`
+
+	unmarshal := newMgmtError()
+	if err := xml.Unmarshal([]byte(rpcErr), unmarshal); err != nil {
+		t.Fatalf("Unmarshal error: %v\n", err)
+	}
+	exp := MgmtErrorInfoTag{
+		XMLName: xml.Name{Space: VyattaNamespace, Local: "limit"},
+		Value:   "1024",
+		Attrs: []xml.Attr{
+			{Name: xml.Name{Local: "scope"}, Value: "session"},
+			{Name: xml.Name{Local: "unit"}, Value: "bytes"},
+		},
+	}
+	if len(unmarshal.Info) != 2 {
+		t.Fatalf("Unexpected info: %#v", unmarshal.Info)
+	}
+	if !reflect.DeepEqual(exp, unmarshal.Info[0]) {
+		t.Errorf("Unexpected info tag")
+		t.Logf("Expected: %#v", exp)
+		t.Logf("Result:   %#v", unmarshal.Info[0])
+	}
+	if unmarshal.Info[1].Attrs != nil {
+		t.Errorf("Unexpected attributes: %v", unmarshal.Info[1].Attrs)
+	}
+
+	verifyXmlMarshal(t, unmarshal, rpcErr)
+
+	// The JSON encoding does not carry the attributes
+	b, err := json.Marshal(unmarshal)
+	if err != nil {
+		t.Fatalf("Marshal error: %v\n", err)
+	}
+	fromJSON := newMgmtError()
+	if err := json.Unmarshal(b, fromJSON); err != nil {
+		t.Fatalf("Unmarshal error: %v\n", err)
+	}
+	if len(fromJSON.Info) != 2 || fromJSON.Info[0].Attrs != nil {
+		t.Errorf("Unexpected info from JSON: %#v", fromJSON.Info)
+	}
+}
+
+func TestMgmtErrorInfoTagAttrNamespace(t *testing.T) {
+	const info = `<limit xmlns="` + VyattaNamespace + `"` +
+		` xmlns:ex="urn:example" ex:unit="bytes" unit="kB">1024</limit>`
+
+	var tag MgmtErrorInfoTag
+	if err := xml.Unmarshal([]byte(info), &tag); err != nil {
+		t.Fatalf("Unmarshal error: %v\n", err)
+	}
+	exp := []xml.Attr{
+		{Name: xml.Name{Space: "urn:example", Local: "unit"}, Value: "bytes"},
+		{Name: xml.Name{Local: "unit"}, Value: "kB"},
+	}
+	if !reflect.DeepEqual(exp, tag.Attrs) {
+		t.Errorf("Unexpected attributes")
+		t.Logf("Expected: %#v", exp)
+		t.Logf("Result:   %#v", tag.Attrs)
+	}
+
+	b, err := xml.Marshal(tag)
+	if err != nil {
+		t.Fatalf("Marshal error: %v\n", err)
+	}
+	var again MgmtErrorInfoTag
+	if err := xml.Unmarshal(b, &again); err != nil {
+		t.Fatalf("Unmarshal error: %v\n%s", err, b)
+	}
+	if !reflect.DeepEqual(tag, again) {
+		t.Errorf("Attributes not preserved: %s", b)
+		t.Logf("Expected: %#v", tag)
+		t.Logf("Result:   %#v", again)
+	}
+}
+
+func TestMgmtErrorUnmarshalXMLPrefixed(t *testing.T) {
+	const rpcErr = `<nc:rpc-error xmlns:nc="` + netconf_namespace + `">
+	<nc:error-type>protocol</nc:error-type>
+	<nc:error-tag>bad-attribute</nc:error-tag>
+	<nc:error-severity>error</nc:error-severity>
+	<nc:error-message>` + msg_nc_bad_attribute + `</nc:error-message>
+	<nc:error-info>
+		<nc:bad-attribute>attr</nc:bad-attribute>
+		<nc:bad-element>elem</nc:bad-element>
+	</nc:error-info>
+</nc:rpc-error>`
+
+	unmarshal := newMgmtError()
+	if err := xml.Unmarshal([]byte(rpcErr), unmarshal); err != nil {
+		t.Fatalf("Unmarshal error: %v\n", err)
+	}
+	cmpMgmtError(t, NewBadAttrProtocolError("attr", "elem").MgmtError,
+		unmarshal)
+}
+
+func TestUnmarshalXMLZeroWrapper(t *testing.T) {
+	marshal, err := xml.Marshal(NewInUseProtocolError())
+	if err != nil {
+		t.Fatalf("Marshal error: %v\n", err)
+	}
+	var unmarshal InUseProtocolError
+	if err := xml.Unmarshal(marshal, &unmarshal); err != nil {
+		t.Fatalf("Unmarshal error: %v\n", err)
+	}
+	cmpMgmtError(t, NewInUseProtocolError().MgmtError, unmarshal.MgmtError)
+}
+
+func TestMgmtErrorInfoNestedJSON(t *testing.T) {
+	// Ordered by namespace, then name, so it survives the round trip
+	info := MgmtErrorInfo{
+		*NewMgmtErrorInfoTag("", "bad-element", "config"),
+		*NewMgmtErrorInfoTag(yang_namespace, "non-unique", "/a/b"),
+		*NewMgmtErrorInfoTag(yang_namespace, "non-unique", "/a/c"),
+		*NewMgmtErrorInfoTag(VyattaNamespace, "limit", "1024"),
+	}
+	marshal, err := info.MarshalNestedJSON()
+	if err != nil {
+		t.Fatalf("Marshal error: %v\n", err)
+	}
+	const expected = `{"":{"bad-element":["config"]},` +
+		`"` + yang_namespace + `":{"non-unique":["/a/b","/a/c"]},` +
+		`"` + VyattaNamespace + `":{"limit":["1024"]}}`
+	if string(marshal) != expected {
+		t.Errorf("Unexpected JSON\n  expect: %s\n  actual: %s\n",
+			expected, marshal)
+	}
+
+	var unmarshal MgmtErrorInfo
+	if err := unmarshal.UnmarshalNestedJSON(marshal); err != nil {
+		t.Fatalf("Unmarshal error: %v\n", err)
+	}
+	if !reflect.DeepEqual(info, unmarshal) {
+		t.Errorf("Unexpected info after round trip")
+		t.Logf("Expected: %#v", info)
+		t.Logf("Result:   %#v", unmarshal)
+	}
+}
+
+type otherFormattable struct{}
+
+func (o otherFormattable) GetMessage() string     { return "" }
+func (o otherFormattable) GetPath() string        { return "" }
+func (o otherFormattable) GetSeverity() string    { return "" }
+func (o otherFormattable) GetTag() string         { return "" }
+func (o otherFormattable) GetAppTag() string      { return "" }
+func (o otherFormattable) GetType() string        { return "" }
+func (o otherFormattable) GetInfo() MgmtErrorInfo { return nil }
+
+func TestAsMgmtError(t *testing.T) {
+	wrapped := NewMustViolationError()
+	me, ok := AsMgmtError(wrapped)
+	if !ok || me != wrapped.MgmtError {
+		t.Errorf("Failed to get MgmtError from %T", wrapped)
+	}
+	me.Path = "/foo"
+	if wrapped.GetPath() != "/foo" {
+		t.Errorf("Modification not seen through wrapper")
+	}
+
+	plain := newMgmtError()
+	if me, ok := AsMgmtError(plain); !ok || me != plain {
+		t.Errorf("Failed to get MgmtError from %T", plain)
+	}
+
+	if _, ok := AsMgmtError(&ExecError{}); ok {
+		t.Errorf("Unexpected MgmtError from empty wrapper")
+	}
+	if _, ok := AsMgmtError(otherFormattable{}); ok {
+		t.Errorf("Unexpected MgmtError from %T", otherFormattable{})
+	}
+}
+
+func TestPrependPath(t *testing.T) {
+	tests := []struct {
+		path, segment, expected string
+	}{
+		{path: "", segment: "foo", expected: "/foo"},
+		{path: "", segment: "/foo/", expected: "/foo"},
+		{path: "/bar", segment: "foo", expected: "/foo/bar"},
+		{path: "bar/baz", segment: "/foo", expected: "/foo/bar/baz"},
+		{path: "/bar", segment: "/foo/biz/", expected: "/foo/biz/bar"},
+		{path: "/bar", segment: "", expected: "/bar"},
+	}
+	for _, test := range tests {
+		err := newMgmtError()
+		err.Path = test.path
+		if got := err.PrependPath(test.segment).Path; got != test.expected {
+			t.Errorf("Prepend %q to %q\n  expect: %s\n  got:    %s",
+				test.segment, test.path, test.expected, got)
+		}
+	}
+
+	err := NewMustViolationError()
+	err.Path = "/name/dev1"
+	err.PrependPath("interfaces").PrependPath("/top")
+	if err.Path != "/top/interfaces/name/dev1" {
+		t.Errorf("Unexpected chained path: %s", err.Path)
+	}
+}
+
+func TestPathWithPrefixes(t *testing.T) {
+	prefixes := map[string]string{
+		"interfaces": "if",
+		"interface":  "if",
+		"system":     "sys",
+	}
+	tests := []struct {
+		path, expected string
+	}{
+		{"/interfaces/interface", "/if:interfaces/if:interface"},
+		{"/interfaces/interface[name='dp0s3']/mtu",
+			"/if:interfaces/if:interface[name='dp0s3']/mtu"},
+		{"/sys:system/ntp", "/sys:system/ntp"},
+		{"/", "/"},
+		{"", ""},
+	}
+	for _, test := range tests {
+		err := newMgmtError()
+		err.Path = test.path
+		if path := err.PathWithPrefixes(prefixes); path != test.expected {
+			t.Errorf("Prefixing %q\n  expect: %s\n  got:    %s",
+				test.path, test.expected, path)
+		}
+		if err.Path != test.path {
+			t.Errorf("Path changed to %q", err.Path)
+		}
+	}
+}
+
+// A cause that would make a cycle is not recorded, so that errors.Is
+// and errors.As return.
+func TestWithCauseCycle(t *testing.T) {
+	target := errors.New("target")
+	a := NewDataMissingError()
+	b := NewDataExistsError()
+	a.WithCause(b)
+
+	tests := []struct {
+		name  string
+		cause error
+	}{
+		{"self", b},
+		{"direct", a},
+		{"wrapped", fmt.Errorf("failed: %w", a)},
+		{"joined", errors.Join(target, a)},
+		{"untyped", a.MgmtError},
+	}
+	for _, test := range tests {
+		b.WithCause(nil)
+		a.WithCause(b)
+		b.WithCause(test.cause)
+
+		done := make(chan bool)
+		go func() { done <- errors.Is(a, target) }()
+		select {
+		case found := <-done:
+			if found {
+				t.Errorf("%s: unexpected cause found", test.name)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("%s: errors.Is did not terminate", test.name)
+		}
+		if b.Unwrap() != nil || !errors.Is(a, b) || errors.Is(b, a) {
+			t.Errorf("%s: cause making a cycle recorded", test.name)
+		}
+	}
+
+	b.WithCause(target)
+	if !errors.Is(a, target) {
+		t.Errorf("Cause not followed")
+	}
+	var existing *DataExistsError
+	if !errors.As(a, &existing) || existing != b {
+		t.Errorf("Typed cause not found")
+	}
+	if b.WithCause(a); !errors.Is(b, target) {
+		t.Errorf("Cause replaced by one making a cycle")
+	}
+}
+
+func TestRelativeTo(t *testing.T) {
+	err := NewNonUniqueError([]string{"/lib/a/x", "/other/b"})
+	err.Path = "/lib/a"
+	err.WithRelatedPaths("/lib/c")
+	err.Info = append(err.Info, *NewMgmtErrorInfoTag(VyattaNamespace,
+		"detail", "/lib/d"))
+
+	rel := err.RelativeTo("/lib/")
+	if rel.Path != "a" {
+		t.Errorf("Unexpected relative path: %s", rel.Path)
+	}
+	expected := []string{"a/x", "/other/b", "c", "/lib/d"}
+	for i, exp := range expected {
+		if rel.Info[i].Value != exp {
+			t.Errorf("Unexpected info path %d\n  expect: %s\n  got:    %s",
+				i, exp, rel.Info[i].Value)
+		}
+	}
+
+	if err.Path != "/lib/a" || err.Info[0].Value != "/lib/a/x" ||
+		err.Info[2].Value != "/lib/c" {
+		t.Errorf("Error changed: %s %v", err.Path, err.Info)
+	}
+	if rel := err.RelativeTo(""); rel.Path != "/lib/a" ||
+		rel.Info[0].Value != "/lib/a/x" {
+		t.Errorf("Paths changed relative to empty base: %s %v",
+			rel.Path, rel.Info)
+	}
+}
+
+func TestRebasePath(t *testing.T) {
+	tests := []struct {
+		path, oldPrefix, newPrefix, expected string
+	}{
+		{path: "/lib/foo", oldPrefix: "/lib", newPrefix: "/top/mod",
+			expected: "/top/mod/foo"},
+		{path: "/lib/foo", oldPrefix: "/lib/", newPrefix: "/top/",
+			expected: "/top/foo"},
+		{path: "/lib", oldPrefix: "/lib", newPrefix: "/top",
+			expected: "/top"},
+		{path: "/lib/foo", oldPrefix: "/lib", newPrefix: "",
+			expected: "/foo"},
+		{path: "/lib", oldPrefix: "/lib", newPrefix: "/",
+			expected: "/"},
+		{path: "/foo", oldPrefix: "/", newPrefix: "/top",
+			expected: "/top/foo"},
+	}
+	for _, test := range tests {
+		err := newMgmtError()
+		err.Path = test.path
+		if e := err.RebasePath(test.oldPrefix, test.newPrefix); e != nil {
+			t.Errorf("Rebase %q from %q: %v",
+				test.path, test.oldPrefix, e)
+			continue
+		}
+		if err.Path != test.expected {
+			t.Errorf("Rebase %q from %q to %q\n  expect: %s\n  got:    %s",
+				test.path, test.oldPrefix, test.newPrefix,
+				test.expected, err.Path)
+		}
+	}
+
+	err := NewNonUniqueError([]string{"/lib/a/x", "/other/b"})
+	err.Path = "/lib/a"
+	err.WithRelatedPaths("/lib/c")
+	if e := err.RebasePath("/lib", "/top"); e != nil {
+		t.Fatalf("Unexpected rebase error: %v", e)
+	}
+	expected := []string{"/top/a/x", "/other/b", "/top/c"}
+	for i, exp := range expected {
+		if err.Info[i].Value != exp {
+			t.Errorf("Unexpected info path %d: %s", i, err.Info[i].Value)
+		}
+	}
+
+	for _, path := range []string{"/library/foo", "/foo", ""} {
+		err := newMgmtError()
+		err.Path = path
+		if e := err.RebasePath("/lib", "/top"); e == nil {
+			t.Errorf("Expected error rebasing %q", path)
+		}
+		if err.Path != path {
+			t.Errorf("Path changed on failure: %s", err.Path)
+		}
+	}
+}
+
+func ExampleMgmtError_WithMessage() {
+	err := NewOperationFailedApplicationError().
+		WithMessage("custom").
+		WithPath("/x")
+	fmt.Println(err.Error())
+
+	// Output:
+	// Error: /x: custom
+}
+
+func ExampleMgmtError_InfoString() {
+	err := NewUnknownNamespaceApplicationError("interfaces",
+		"urn:example:interfaces")
+	err.WithModule("example-interfaces", "")
+	fmt.Println(err.InfoString())
+
+	// Output:
+	//   bad-element: interfaces
+	//   bad-namespace: urn:example:interfaces
+	//   module: example-interfaces
+}
+
+func TestInfoStringEmpty(t *testing.T) {
+	if s := newMgmtError().InfoString(); s != "" {
+		t.Errorf("Unexpected info string: %q", s)
+	}
+}
+
+func TestGetSeverityDefault(t *testing.T) {
+	const input = `<rpc-reply xmlns="` + netconf_namespace + `">` +
+		`<rpc-error><error-type>application</error-type>` +
+		`<error-tag>operation-failed</error-tag>` +
+		`<error-path>/foo</error-path>` +
+		`<error-message>boom</error-message></rpc-error>` +
+		`<rpc-error><error-type>application</error-type>` +
+		`<error-tag>operation-failed</error-tag>` +
+		`<error-app-tag>must-violation</error-app-tag>` +
+		`<error-path>/foo</error-path>` +
+		`<error-message>must failed</error-message></rpc-error>` +
+		`</rpc-reply>`
+
+	errs, err := ParseRPCReply(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected parse error: %v", err)
+	}
+	expected := []string{"Error: /foo: boom", "Error: /foo: must failed"}
+	for i, e := range errs.Errors() {
+		me := asMgmtError(e)
+		if me.Severity != "" {
+			t.Errorf("%d: unexpected decoded severity %q", i, me.Severity)
+		}
+		if sev := me.GetSeverity(); sev != "error" {
+			t.Errorf("%d: unexpected default severity %q", i, sev)
+		}
+		if e.Error() != expected[i] {
+			t.Errorf("%d: unexpected error string\n  expect: %s\n  got:    %s",
+				i, expected[i], e.Error())
+		}
+	}
+}
+
+func TestDBusErrorName(t *testing.T) {
+	noType := NewInUseProtocolError().MgmtError
+	noType.Typ = ""
+	badType := NewInUseProtocolError().MgmtError
+	badType.Typ = "session"
+	badTag := NewInUseProtocolError().MgmtError
+	badTag.Tag = "not-a-tag"
+
+	tests := []struct {
+		name   string
+		err    *MgmtError
+		byType string
+		byTag  string
+	}{
+		{"protocol", NewInUseProtocolError().MgmtError,
+			"com.vyatta.rpcerror.protocol", "com.vyatta.rpcerror.in_use"},
+		{"application", NewAccessDeniedApplicationError().MgmtError,
+			"com.vyatta.rpcerror.application",
+			"com.vyatta.rpcerror.access_denied"},
+		{"no type", noType,
+			"com.vyatta.rpcerror.application", "com.vyatta.rpcerror.in_use"},
+		{"invalid type", badType,
+			"com.vyatta.rpcerror.application", "com.vyatta.rpcerror.in_use"},
+		{"invalid tag", badTag,
+			"com.vyatta.rpcerror.protocol",
+			"com.vyatta.rpcerror.operation_failed"},
+	}
+	for _, test := range tests {
+		if name, _ := test.err.DBusError(); name != test.byType {
+			t.Errorf("%s: unexpected name %s", test.name, name)
+		}
+	}
+
+	DBusErrorNameByTag = true
+	defer func() { DBusErrorNameByTag = false }()
+	for _, test := range tests {
+		name, body := test.err.DBusError()
+		if name != test.byTag {
+			t.Errorf("%s: unexpected name by tag %s", test.name, name)
+		}
+		if len(body) != 1 || body[0] != test.err {
+			t.Errorf("%s: unexpected body %v", test.name, body)
+		}
+	}
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	info := MgmtErrorInfo{
+		*NewMgmtErrorInfoTag(VyattaNamespace, "limit", "1024"),
+		*NewMgmtErrorInfoTag(yang_namespace, "non-unique", "/a/c"),
+		*NewMgmtErrorInfoTag("", "bad-element", "config"),
+		*NewMgmtErrorInfoTag(yang_namespace, "non-unique", "/a/b"),
+	}
+	reversed := make(MgmtErrorInfo, 0, len(info))
+	for i := len(info) - 1; i >= 0; i-- {
+		reversed = append(reversed, info[i])
+	}
+	err1 := newMgmtError()
+	err1.Info = info
+	err2 := newMgmtError()
+	err2.Info = reversed
+
+	canon1, err := err1.CanonicalJSON()
+	if err != nil {
+		t.Fatalf("Canonical JSON error: %v\n", err)
+	}
+	canon2, err := err2.CanonicalJSON()
+	if err != nil {
+		t.Fatalf("Canonical JSON error: %v\n", err)
+	}
+	if string(canon1) != string(canon2) {
+		t.Errorf("Canonical JSON differs\n  %s\n  %s", canon1, canon2)
+	}
+	if !reflect.DeepEqual(err1.Info, info) {
+		t.Errorf("Info modified by CanonicalJSON: %v", err1.Info)
+	}
+
+	const expInfo = `"error-info":[{"bad-element":"config"},` +
+		`{"ietf-yang:non-unique":"/a/b"},` +
+		`{"ietf-yang:non-unique":"/a/c"},` +
+		`{"vyatta-yang:limit":"1024"}]`
+	if !strings.Contains(string(canon1), expInfo) {
+		t.Errorf("Unexpected canonical JSON: %s", canon1)
+	}
+}
+
+func TestLookupAppTagAndPath(t *testing.T) {
+	const (
+		present = `{"error-type":"application","error-tag":"in-use",` +
+			`"error-severity":"error","error-app-tag":"","error-path":""}`
+		absent = `{"error-type":"application","error-tag":"in-use",` +
+			`"error-severity":"error"}`
+		presentXml = `<rpc-error xmlns="` + netconf_namespace + `">` +
+			`<error-type>application</error-type>` +
+			`<error-tag>in-use</error-tag>` +
+			`<error-severity>error</error-severity>` +
+			`<error-app-tag></error-app-tag>` +
+			`<error-path/>` +
+			`</rpc-error>`
+		absentXml = `<rpc-error xmlns="` + netconf_namespace + `">` +
+			`<error-type>application</error-type>` +
+			`<error-tag>in-use</error-tag>` +
+			`<error-severity>error</error-severity>` +
+			`</rpc-error>`
+	)
+	tests := []struct {
+		name      string
+		unmarshal func([]byte, interface{}) error
+		input     string
+		present   bool
+	}{
+		{"JSON present", json.Unmarshal, present, true},
+		{"JSON absent", json.Unmarshal, absent, false},
+		{"XML present", xml.Unmarshal, presentXml, true},
+		{"XML absent", xml.Unmarshal, absentXml, false},
+	}
+	for _, test := range tests {
+		e := newMgmtError()
+		if err := test.unmarshal([]byte(test.input), e); err != nil {
+			t.Errorf("%s: unmarshal error: %v", test.name, err)
+			continue
+		}
+		if tag, ok := e.LookupAppTag(); tag != "" || ok != test.present {
+			t.Errorf("%s: unexpected app-tag lookup: %q, %v",
+				test.name, tag, ok)
+		}
+		if path, ok := e.LookupPath(); path != "" || ok != test.present {
+			t.Errorf("%s: unexpected path lookup: %q, %v",
+				test.name, path, ok)
+		}
+	}
+
+	e := NewMustViolationError()
+	e.Path = "/foo"
+	if tag, ok := e.LookupAppTag(); tag != must_violation.String() || !ok {
+		t.Errorf("Unexpected app-tag lookup: %q, %v", tag, ok)
+	}
+	if path, ok := e.LookupPath(); path != "/foo" || !ok {
+		t.Errorf("Unexpected path lookup: %q, %v", path, ok)
+	}
+	if _, ok := NewInUseApplicationError().WithAppTag("").LookupAppTag(); !ok {
+		t.Errorf("Empty app-tag set with WithAppTag not present")
+	}
+}
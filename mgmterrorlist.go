@@ -12,13 +12,52 @@ import (
 	"bytes"
 	"encoding/json"
 	"encoding/xml"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type MgmtErrorList struct {
-	errs []error
+	entries []listEntry
+
+	// nextSeq is the sequence number of the next error appended
+	nextSeq int
+}
+
+// listEntry is an error in a MgmtErrorList, with the sequence number
+// recording when it was appended, so that detection order can be
+// restored by SortBySequence.
+type listEntry struct {
+	err error
+	seq int
+}
+
+// Errors returns the errors in the list. The slice is a copy, so
+// reordering it does not reorder the list; use Sort for that.
+func (e MgmtErrorList) Errors() []error {
+	errs := make([]error, len(e.entries))
+	for i, ent := range e.entries {
+		errs[i] = ent.err
+	}
+	return errs
 }
 
-func (e MgmtErrorList) Errors() []error { return e.errs }
+// Len returns the number of errors in the list.
+func (e MgmtErrorList) Len() int { return len(e.entries) }
+
+// Reset empties the list, keeping the storage allocated for the errors
+// so that the list can be reused, eg in a validation loop.
+func (e *MgmtErrorList) Reset() {
+	// Release the errors themselves
+	for i := range e.entries {
+		e.entries[i].err = nil
+	}
+	e.entries = e.entries[:0]
+	e.nextSeq = 0
+}
 
 // Make sure the error has either a JSON or XML Marshaler.  If not,
 // convert the "error" to a standard error.
@@ -27,11 +66,7 @@ func mkMgmtError(e error) error {
 	case json.Marshaler, xml.Marshaler:
 		return e
 	case Formattable:
-		me, _ := e.(Formattable)
-		err := NewOperationFailedApplicationError()
-		err.Message = me.GetMessage()
-		err.Path = me.GetPath()
-		return err
+		return formattableMgmtError(e.(Formattable))
 	default:
 		err := NewOperationFailedApplicationError()
 		err.Message = e.Error()
@@ -39,21 +74,60 @@ func mkMgmtError(e error) error {
 	}
 }
 
+// formattableMgmtError converts a Formattable error to the equivalent
+// MgmtError type, copying all its fields. Any of type, tag and severity
+// which are not set are those of an operation-failed application error.
+func formattableMgmtError(f Formattable) error {
+	err := NewOperationFailedApplicationError().MgmtError
+	if typ := f.GetType(); typ != "" {
+		err.Typ = typ
+	}
+	if tag := f.GetTag(); tag != "" {
+		err.Tag = tag
+	}
+	if sev := f.GetSeverity(); sev != "" {
+		err.Severity = sev
+	}
+	err.AppTag = f.GetAppTag()
+	err.Path = f.GetPath()
+	err.Message = undecoratedMessage(f)
+	err.Info = append(MgmtErrorInfo(nil), f.GetInfo()...)
+	return typedError(err)
+}
+
 func (e MgmtErrorList) MarshalJSON() ([]byte, error) {
 	var out bytes.Buffer
-	out.WriteString("{\"error-list\":[")
-	for i, err := range e.errs {
-		b, e := json.Marshal(err)
+	out.WriteString("{\"error-list\":")
+	if err := e.writeJSONArray(&out); err != nil {
+		return out.Bytes(), err
+	}
+	out.WriteString("}")
+	return out.Bytes(), nil
+}
+
+// MarshalJSONArray encodes the list as a plain JSON array of errors,
+// rather than the error-list object of MarshalJSON, for consumers that
+// expect a top-level array.
+func (e MgmtErrorList) MarshalJSONArray() ([]byte, error) {
+	var out bytes.Buffer
+	err := e.writeJSONArray(&out)
+	return out.Bytes(), err
+}
+
+func (e MgmtErrorList) writeJSONArray(out *bytes.Buffer) error {
+	out.WriteByte('[')
+	for i, ent := range e.entries {
+		b, e := json.Marshal(ent.err)
 		if e != nil {
-			return out.Bytes(), e
+			return e
 		}
 		if i > 0 {
 			out.WriteByte(',')
 		}
 		out.Write(b)
 	}
-	out.WriteString("]}")
-	return out.Bytes(), nil
+	out.WriteByte(']')
+	return nil
 }
 
 func (e *MgmtErrorList) UnmarshalJSON(value []byte) error {
@@ -63,49 +137,533 @@ func (e *MgmtErrorList) UnmarshalJSON(value []byte) error {
 	if err := json.Unmarshal(value, &errs); err != nil {
 		return err
 	}
-	e.errs = []error{}
-	for _, err := range errs.ErrorList {
-		err.setXMLName()
-		// NETCONF errors are the most generic (don't use
-		// app-tag) so search them last.
-		if vyerr := getVyattaError(err); vyerr != nil {
-			e.MgmtErrorListAppend(vyerr)
-		} else if yerr := getYangError(err); yerr != nil {
-			e.MgmtErrorListAppend(yerr)
-		} else if ncerr := getNetconfError(err); ncerr != nil {
-			e.MgmtErrorListAppend(ncerr)
-		} else {
-			e.MgmtErrorListAppend(err)
-		}
+	e.setDecoded(errs.ErrorList)
+	return nil
+}
+
+// UnmarshalJSONArray decodes a plain JSON array of errors, as encoded by
+// MarshalJSONArray, replacing the content of the list.
+func (e *MgmtErrorList) UnmarshalJSONArray(value []byte) error {
+	var errs []*MgmtError
+	if err := json.Unmarshal(value, &errs); err != nil {
+		return err
 	}
+	e.setDecoded(errs)
 	return nil
 }
 
+func (e *MgmtErrorList) setDecoded(errs []*MgmtError) {
+	e.entries = []listEntry{}
+	e.nextSeq = 0
+	for _, err := range errs {
+		e.MgmtErrorListAppend(typedError(err))
+	}
+}
+
+// typedError returns the specific error type for a decoded error, or
+// the error itself if it is not recognised.
+func typedError(err *MgmtError) error {
+	err.setXMLName()
+	// NETCONF errors are the most generic (don't use
+	// app-tag) so search them last.
+	if vyerr := getVyattaError(err); vyerr != nil {
+		return vyerr
+	} else if yerr := getYangError(err); yerr != nil {
+		return yerr
+	} else if ncerr := getNetconfError(err); ncerr != nil {
+		return ncerr
+	}
+	return err
+}
+
 func (e MgmtErrorList) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
-	for _, err := range e.errs {
-		if e := enc.Encode(err); e != nil {
+	for _, ent := range e.entries {
+		if e := enc.Encode(withPathPrefixes(ent.err)); e != nil {
 			return e
 		}
 	}
 	return nil
 }
 
+// withPathPrefixes returns a copy of err with its error-path qualified
+// by ErrorPathPrefixes, or err itself if there is nothing to qualify.
+func withPathPrefixes(err error) interface{} {
+	ref, ok := err.(MgmtErrorRef)
+	if !ok || len(ErrorPathPrefixes) == 0 {
+		return err
+	}
+	me := ref.getMgmtError()
+	path := me.PathWithPrefixes(ErrorPathPrefixes)
+	if path == me.Path {
+		return err
+	}
+	c := *me
+	c.Path = path
+	return &c
+}
+
+// RFC5277 Sect 4
+const notification_namespace = "urn:ietf:params:xml:ns:netconf:notification:1.0"
+
+// MarshalNotification encodes the list as the content of a NETCONF
+// event notification, for reporting errors asynchronously rather than in
+// an rpc-reply. The errors are carried in a Vyatta error-list element.
+func (e MgmtErrorList) MarshalNotification(eventTime time.Time) ([]byte, error) {
+	var out bytes.Buffer
+	enc := xml.NewEncoder(&out)
+
+	notif := xml.StartElement{
+		Name: xml.Name{Space: notification_namespace, Local: "notification"},
+	}
+	list := xml.StartElement{
+		Name: xml.Name{Space: VyattaNamespace, Local: "error-list"},
+	}
+
+	if err := enc.EncodeToken(notif); err != nil {
+		return nil, err
+	}
+	if err := enc.EncodeElement(eventTime.Format(time.RFC3339),
+		xml.StartElement{Name: xml.Name{Local: "eventTime"}}); err != nil {
+		return nil, err
+	}
+	if err := enc.EncodeToken(list); err != nil {
+		return nil, err
+	}
+	if err := e.MarshalXML(enc, list); err != nil {
+		return nil, err
+	}
+	if err := enc.EncodeToken(list.End()); err != nil {
+		return nil, err
+	}
+	if err := enc.EncodeToken(notif.End()); err != nil {
+		return nil, err
+	}
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
 func (e *MgmtErrorList) MgmtErrorListAppend(errs ...error) {
 	for _, err := range errs {
-		e.errs = append(e.errs, mkMgmtError(err))
+		e.appendError(mkMgmtError(err))
+	}
+}
+
+// appendError appends err without converting it.
+func (e *MgmtErrorList) appendError(err error) {
+	e.entries = append(e.entries, listEntry{err: err, seq: e.nextSeq})
+	e.nextSeq++
+}
+
+// FromJoined returns a list of the errors joined in err, as by
+// errors.Join, ie any error with an Unwrap() []error method. Joined
+// errors, and MgmtErrorLists, nested within it are flattened into the
+// list. An err that is not joined gives a list of just that error.
+//
+// Each joined error is expanded once, so that an err which contains
+// itself, directly or indirectly, cannot recurse forever. A joined
+// error which cannot be identified, as it is neither comparable nor a
+// slice, is only expanded to a depth of maxUnwrapDepth.
+func FromJoined(err error) MgmtErrorList {
+	var list MgmtErrorList
+	list.entries = []listEntry{}
+	list.appendJoined(err, make(map[interface{}]bool), 0)
+	return list
+}
+
+// The depth to which FromJoined and WithCause follow wrapped errors
+// they cannot identify
+const maxUnwrapDepth = 64
+
+func (e *MgmtErrorList) appendJoined(
+	err error,
+	expanded map[interface{}]bool,
+	depth int,
+) {
+	switch joined := err.(type) {
+	case nil:
+	case interface{ Unwrap() []error }:
+		if key, ok := errorKey(err); ok {
+			if expanded[key] {
+				return
+			}
+			expanded[key] = true
+		} else if depth >= maxUnwrapDepth {
+			return
+		}
+		for _, err := range joined.Unwrap() {
+			e.appendJoined(err, expanded, depth+1)
+		}
+	case MgmtErrorList:
+		e.MgmtErrorListAppend(joined.Errors()...)
+	case *MgmtErrorList:
+		e.MgmtErrorListAppend(joined.Errors()...)
+	default:
+		e.MgmtErrorListAppend(err)
+	}
+}
+
+// errorSliceKey identifies an error which is a slice, eg a joined
+// error, by its content.
+type errorSliceKey struct {
+	typ  reflect.Type
+	data uintptr
+	len  int
+}
+
+// errorKey returns a key identifying err, for recording the errors
+// already visited when following wrapped errors, or false if it cannot
+// be identified.
+func errorKey(err error) (interface{}, bool) {
+	if reflect.TypeOf(err).Comparable() {
+		return err, true
+	}
+	if v := reflect.ValueOf(err); v.Kind() == reflect.Slice {
+		return errorSliceKey{v.Type(), v.Pointer(), v.Len()}, true
+	}
+	return nil, false
+}
+
+// SingletonList returns a list of just err, converted as by
+// MgmtErrorListAppend. If err is already a MgmtErrorList it is returned
+// unchanged, rather than being nested. A nil err gives an empty list.
+func SingletonList(err error) MgmtErrorList {
+	switch list := err.(type) {
+	case MgmtErrorList:
+		return list
+	case *MgmtErrorList:
+		if list != nil {
+			return *list
+		}
+	}
+	var list MgmtErrorList
+	list.entries = []listEntry{}
+	if err != nil {
+		list.MgmtErrorListAppend(err)
+	}
+	return list
+}
+
+// Separates the messages of errors merged by GroupByPath
+const groupedMessageSeparator = "; "
+
+// pathOf returns the error-path of err, or "" if it is not based on a
+// MgmtError.
+func pathOf(err error) string {
+	if ref, ok := err.(MgmtErrorRef); ok {
+		if me := ref.getMgmtError(); me != nil {
+			return me.Path
+		}
+	}
+	return ""
+}
+
+// GroupByPath returns a list in which the errors sharing an error-path
+// are merged into a single error, so that the problems with a node are
+// reported together. The merged error takes the type, tag, severity and
+// app-tag of the first error at the path. Its message lists the message
+// of each error, which is also kept in a Vyatta "message" info tag, and
+// it has the info tags of all the errors, merged as by
+// MgmtError.MergeInfo.
+//
+// As an error has a single app-tag, conflicting app-tags are resolved
+// by AppTagPriority: the merged error keeps the highest priority app-tag
+// of the errors, or the first if none is listed, and each other app-tag
+// is kept in a Vyatta "app-tag" info tag. An error without an app-tag
+// does not conflict, so any app-tag is preferred to none.
+//
+// Errors without a path, or not based on a MgmtError, are not merged.
+// The merged error is placed where the first error at its path was. It
+// is a *MgmtError, rather than the specific type for its tag and
+// app-tag, whose message may be composed differently.
+func (e MgmtErrorList) GroupByPath() MgmtErrorList {
+	byPath := make(map[string][]error)
+	for _, ent := range e.entries {
+		if path := pathOf(ent.err); path != "" {
+			byPath[path] = append(byPath[path], ent.err)
+		}
+	}
+
+	entries := []listEntry{}
+	done := make(map[string]bool)
+	for _, ent := range e.entries {
+		path := pathOf(ent.err)
+		if len(byPath[path]) < 2 {
+			entries = append(entries, ent)
+			continue
+		}
+		if !done[path] {
+			done[path] = true
+			entries = append(entries, listEntry{
+				err: mergeErrors(byPath[path]),
+				seq: ent.seq,
+			})
+		}
+	}
+	return derivedList(entries)
+}
+
+// AppTagPriority lists error-app-tags in decreasing order of priority,
+// as used to resolve conflicting app-tags by MgmtErrorList.GroupByPath.
+// Tags not listed have lower priority than those that are. It is empty
+// by default, when the app-tag of the first error is kept.
+var AppTagPriority []string
+
+func appTagRank(appTag string) int {
+	for i, t := range AppTagPriority {
+		if t == appTag {
+			return i
+		}
+	}
+	return len(AppTagPriority)
+}
+
+// mergeAppTags returns the app-tag to keep for the merged errors, and
+// the other distinct app-tags, as described for GroupByPath.
+func mergeAppTags(errs []error) (string, []string) {
+	keep := errs[0].(Formattable).GetAppTag()
+	seen := map[string]bool{keep: true}
+	var others []string
+	for _, err := range errs[1:] {
+		appTag := err.(Formattable).GetAppTag()
+		if seen[appTag] || appTag == "" {
+			continue
+		}
+		seen[appTag] = true
+		if keep == "" || appTagRank(appTag) < appTagRank(keep) {
+			appTag, keep = keep, appTag
+		}
+		if appTag != "" {
+			others = append(others, appTag)
+		}
+	}
+	return keep, others
+}
+
+// mergeErrors merges errors based on MgmtErrors, as described for
+// GroupByPath.
+func mergeErrors(errs []error) *MgmtError {
+	merged := *errs[0].(MgmtErrorRef).getMgmtError()
+	merged.Info = nil
+	var msgs []string
+	for _, err := range errs {
+		msg := undecoratedMessage(err.(Formattable))
+		msgs = append(msgs, msg)
+		merged.Info = append(merged.Info, *NewMgmtErrorInfoTag(
+			VyattaNamespace, message_info.String(), msg))
+	}
+	appTag, others := mergeAppTags(errs)
+	merged.AppTag = appTag
+	for _, other := range others {
+		merged.Info = append(merged.Info, *NewMgmtErrorInfoTag(
+			VyattaNamespace, app_tag_info.String(), other))
 	}
+	for _, err := range errs {
+		merged.MergeInfo(err.(MgmtErrorRef).getMgmtError())
+	}
+	merged.Message = strings.Join(msgs, groupedMessageSeparator)
+	return &merged
+}
+
+// SortBySequence restores the errors to the order in which they were
+// appended to the list, as RFC6241 expects errors in an rpc-reply to
+// be reported in the order they were detected, eg after the list has
+// been sorted by Sort. The lists returned by Truncate, ClientFacing and
+// GroupByPath keep the order in which their errors were appended to
+// the original list.
+func (e *MgmtErrorList) SortBySequence() {
+	sort.SliceStable(e.entries, func(i, j int) bool {
+		return e.entries[i].seq < e.entries[j].seq
+	})
+}
+
+// Sort sorts the errors in the list by less, keeping the order of
+// equal errors, eg to group them for display. Each error keeps the
+// sequence in which it was appended, so SortBySequence can restore the
+// order in which they were detected.
+func (e *MgmtErrorList) Sort(less func(a, b error) bool) {
+	sort.SliceStable(e.entries, func(i, j int) bool {
+		return less(e.entries[i].err, e.entries[j].err)
+	})
+}
+
+// derivedList returns a list of entries taken from another list,
+// numbered afresh in the order of their sequence there, so that the
+// list is the same as one built by appending them in that order.
+func derivedList(entries []listEntry) MgmtErrorList {
+	bySeq := make([]int, len(entries))
+	for i := range bySeq {
+		bySeq[i] = i
+	}
+	sort.SliceStable(bySeq, func(i, j int) bool {
+		return entries[bySeq[i]].seq < entries[bySeq[j]].seq
+	})
+	for seq, i := range bySeq {
+		entries[i].seq = seq
+	}
+	return MgmtErrorList{entries: entries, nextSeq: len(entries)}
 }
 
+// Truncate returns a list of at most the first max errors in the list,
+// so that the size of a response can be capped. If any errors are
+// omitted, an operation-failed error saying how many is appended.
+func (e MgmtErrorList) Truncate(max int) MgmtErrorList {
+	if max < 0 {
+		max = 0
+	}
+	if len(e.entries) <= max {
+		return e
+	}
+	entries := append([]listEntry{}, e.entries[:max]...)
+	overflow := NewOperationFailedApplicationError()
+	overflow.Message = formatMessage(ErrorsOmittedMessage,
+		strconv.Itoa(len(e.entries)-max))
+	entries = append(entries, listEntry{err: overflow, seq: e.nextSeq})
+	return derivedList(entries)
+}
+
+// ClientFacing returns the list without the errors marked as internal
+// by MgmtError.MarkInternal, for output to end users. The full list
+// should still be logged.
+func (e MgmtErrorList) ClientFacing() MgmtErrorList {
+	entries := []listEntry{}
+	for _, ent := range e.entries {
+		if ref, ok := ent.err.(MgmtErrorRef); ok {
+			if me := ref.getMgmtError(); me != nil && me.IsInternal() {
+				continue
+			}
+		}
+		entries = append(entries, ent)
+	}
+	return derivedList(entries)
+}
+
+// relevance ranks err for Primary, lower being more relevant.
+func relevance(err error, tagPriority []string) (severity, tag int) {
+	tag = len(tagPriority)
+	f, ok := err.(Formattable)
+	if !ok {
+		return 0, tag
+	}
+	if f.GetSeverity() == nc_severity_warning.String() {
+		severity = 1
+	}
+	for i, t := range tagPriority {
+		if f.GetTag() == t {
+			tag = i
+			break
+		}
+	}
+	return severity, tag
+}
+
+// Primary returns the most relevant error in the list, for when only
+// one can be displayed. Errors are more relevant than warnings, then
+// errors are ordered by their tag, as listed in tagPriority in
+// decreasing order of relevance, then by their position in the list.
+// Tags not listed are less relevant than those that are. It returns nil
+// for an empty list.
+func (e MgmtErrorList) Primary(tagPriority ...string) error {
+	var primary error
+	var primarySev, primaryTag int
+	for _, ent := range e.entries {
+		sev, tag := relevance(ent.err, tagPriority)
+		if primary == nil || sev < primarySev ||
+			(sev == primarySev && tag < primaryTag) {
+			primary, primarySev, primaryTag = ent.err, sev, tag
+		}
+	}
+	return primary
+}
+
+// ErrorListSeparator separates the errors in the string returned by
+// MgmtErrorList.Error().
+var ErrorListSeparator = "\n"
+
 func (e MgmtErrorList) Error() string {
-	var b bytes.Buffer
+	return e.JoinedError(ErrorListSeparator)
+}
 
-	for i, err := range e.errs {
-		if i > 0 {
-			b.WriteByte('\n')
+// JoinedError returns the errors in the list as a single string,
+// separated by sep. This allows eg a single line to be logged.
+func (e MgmtErrorList) JoinedError(sep string) string {
+	strs := make([]string, len(e.entries))
+	for i, ent := range e.entries {
+		strs[i] = ent.err.Error()
+	}
+	return strings.Join(strs, sep)
+}
+
+// Errors in a list that are not Formattable are counted under this key
+const unknownCountKey = "unknown"
+
+func (e MgmtErrorList) countBy(key func(Formattable) string) map[string]int {
+	counts := make(map[string]int)
+	for _, ent := range e.entries {
+		if me, ok := ent.err.(Formattable); ok {
+			counts[key(me)]++
+		} else {
+			counts[unknownCountKey]++
 		}
-		b.WriteString(err.Error())
 	}
-	return b.String()
+	return counts
+}
+
+// CountByTag returns the number of errors in the list with each
+// error-tag.
+func (e MgmtErrorList) CountByTag() map[string]int {
+	return e.countBy(func(me Formattable) string { return me.GetTag() })
+}
+
+// CountBySeverity returns the number of errors in the list with each
+// error-severity.
+func (e MgmtErrorList) CountBySeverity() map[string]int {
+	return e.countBy(func(me Formattable) string { return me.GetSeverity() })
+}
+
+// Contains reports whether pred is true for any error in the list,
+// including any that are not Formattable.
+func (e MgmtErrorList) Contains(pred func(error) bool) bool {
+	for _, ent := range e.entries {
+		if pred(ent.err) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsTag reports whether the list has an error with the given
+// error-tag.
+func (e MgmtErrorList) ContainsTag(tag string) bool {
+	return e.Contains(func(err error) bool {
+		me, ok := err.(Formattable)
+		return ok && me.GetTag() == tag
+	})
+}
+
+// asMgmtError returns the MgmtError underlying err, or a generic
+// operation-failed error with the message of err if it has none.
+func asMgmtError(err error) *MgmtError {
+	if ref, ok := err.(MgmtErrorRef); ok {
+		if me := ref.getMgmtError(); me != nil {
+			return me
+		}
+	}
+	me := NewOperationFailedApplicationError().MgmtError
+	me.Message = err.Error()
+	return me
+}
+
+// MgmtErrors returns the errors in the list as MgmtErrors, extracting
+// the MgmtError from each of the specific error types. Any error not
+// based on a MgmtError is reported as a generic operation-failed error.
+func (e MgmtErrorList) MgmtErrors() []*MgmtError {
+	errs := make([]*MgmtError, 0, len(e.entries))
+	for _, ent := range e.entries {
+		errs = append(errs, asMgmtError(ent.err))
+	}
+	return errs
 }
 
 type Formatter func(err error) string
@@ -119,19 +677,79 @@ func (e MgmtErrorList) CustomError(fmtFn Formatter) string {
 		}
 	}
 
-	for i, err := range e.errs {
+	for i, ent := range e.entries {
 		if i > 0 {
 			b.WriteByte('\n')
 		}
-		b.WriteString(fmtFn(err))
+		b.WriteString(fmtFn(ent.err))
 	}
 
 	return b.String()
 }
 
+const dbusErrorListName = "com.vyatta.mgmterror.list"
+
 // Encode error for DBus
 func (e *MgmtErrorList) DBusError() (string, []interface{}) {
 	body := make([]interface{}, 1)
 	body[0] = e
-	return "com.vyatta.mgmterror.list", body
+	return dbusErrorListName, body
+}
+
+// ParseDBusErrorList decodes a DBus error encoded by the DBusError
+// method of a MgmtErrorList, or of a single MgmtError, to a list of the
+// specific error types. The body may hold the errors themselves or
+// their JSON encoding.
+func ParseDBusErrorList(name string, body []interface{}) (MgmtErrorList, error) {
+	var list MgmtErrorList
+	list.entries = []listEntry{}
+
+	if len(body) != 1 {
+		return list, fmt.Errorf("DBus error %s: expected 1 body "+
+			"element, got %d", name, len(body))
+	}
+	var data []byte
+	switch b := body[0].(type) {
+	case string:
+		data = []byte(b)
+	case []byte:
+		data = b
+	}
+
+	switch {
+	case name == dbusErrorListName:
+		switch b := body[0].(type) {
+		case *MgmtErrorList:
+			if b != nil {
+				list.MgmtErrorListAppend(b.Errors()...)
+			}
+			return list, nil
+		case MgmtErrorList:
+			list.MgmtErrorListAppend(b.Errors()...)
+			return list, nil
+		}
+		if data != nil {
+			err := json.Unmarshal(data, &list)
+			return list, err
+		}
+	case strings.HasPrefix(name, errpfx):
+		me, ok := body[0].(*MgmtError)
+		if !ok && data != nil {
+			me = newMgmtError()
+			if err := json.Unmarshal(data, me); err != nil {
+				return list, err
+			}
+			ok = true
+		}
+		if ok {
+			if me != nil {
+				list.MgmtErrorListAppend(typedError(me))
+			}
+			return list, nil
+		}
+	default:
+		return list, fmt.Errorf("not a management error: %s", name)
+	}
+	return list, fmt.Errorf("DBus error %s: unexpected body %T", name,
+		body[0])
 }
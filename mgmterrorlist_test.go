@@ -11,6 +11,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"github.com/danos/utils/pathutil"
 	"github.com/kr/pretty"
@@ -18,6 +19,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 type testMgmtError struct {
@@ -102,6 +104,90 @@ func TestMgmtErrorListJSON(t *testing.T) {
 	}
 }
 
+func TestMgmtErrorListJSONArray(t *testing.T) {
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(NewInUseProtocolError(),
+		NewNonUniqueError([]string{"/a/b", "/a/c"}),
+		NewExecError([]string{"usr", "bin", "app"}, "failed"))
+
+	array, err := errs.MarshalJSONArray()
+	if err != nil {
+		t.Fatalf("Marshal array error: %v", err)
+	}
+	object, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	expected := `{"error-list":` + string(array) + `}`
+	if string(object) != expected {
+		t.Errorf("Array is not the content of the error-list\n"+
+			"  expect: %s\n  got:    %s", expected, object)
+	}
+
+	var decoded MgmtErrorList
+	if err := decoded.UnmarshalJSONArray(array); err != nil {
+		t.Fatalf("Unmarshal array error: %v", err)
+	}
+	if !reflect.DeepEqual(errs, decoded) {
+		t.Errorf("Unexpected errors decoded from %s", array)
+		t.Logf("Expected: %# v", pretty.Formatter(errs))
+		t.Logf("Result:   %# v", pretty.Formatter(decoded))
+	}
+
+	empty, err := MgmtErrorList{}.MarshalJSONArray()
+	if err != nil || string(empty) != "[]" {
+		t.Errorf("Unexpected empty array: %s, %v", empty, err)
+	}
+	if err := decoded.UnmarshalJSONArray([]byte(`{"error-list":[]}`)); err == nil {
+		t.Errorf("Object decoded as an array")
+	}
+}
+
+func TestMgmtErrorListJSONUnrecognised(t *testing.T) {
+	const input = `{"error-list":[` +
+		`{"error-type":"application","error-tag":"frobnicated",` +
+		`"error-severity":"warning","error-app-tag":"not-an-app-tag",` +
+		`"error-path":"/foo/bar","error-message":"Frobnication failed",` +
+		`"error-info":[{"widget":"7"},` +
+		`{"ietf-yang:non-unique":"/foo/bar/baz"},` +
+		`{"acme-module:gadget":"x"}]},` +
+		`{"error-type":"transport","error-tag":"in-use",` +
+		`"error-severity":"error"}]}`
+
+	var errs MgmtErrorList
+	if err := json.Unmarshal([]byte(input), &errs); err != nil {
+		t.Fatalf("Unmarshal MgmtErrorList error: %v\n", err)
+	}
+	for i, err := range errs.Errors() {
+		if _, ok := err.(*MgmtError); !ok {
+			t.Errorf("Error %d unexpectedly recognised as %T", i, err)
+		}
+	}
+	marshal, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatalf("Marshal MgmtErrorList error: %v\n", err)
+	}
+	if string(marshal) != input {
+		t.Errorf("Unrecognised errors changed by round trip\n"+
+			"  expect: %s\n  got:    %s", input, marshal)
+	}
+
+	xmlData, err := xml.Marshal(errs)
+	if err != nil {
+		t.Fatalf("Marshal MgmtErrorList XML error: %v\n", err)
+	}
+	reply, err := ParseRPCReply(strings.NewReader(
+		`<rpc-reply xmlns="` + netconf_namespace + `">` +
+			string(xmlData) + `</rpc-reply>`))
+	if err != nil {
+		t.Fatalf("Parse rpc-reply error: %v\n", err)
+	}
+	if marshal, _ := json.Marshal(reply); string(marshal) != input {
+		t.Errorf("Unrecognised errors changed by XML round trip\n"+
+			"  expect: %s\n  got:    %s", input, marshal)
+	}
+}
+
 func TestMgmtErrorListXML(t *testing.T) {
 	var n uint
 	gen := func() *testMgmtError {
@@ -196,3 +282,732 @@ func ExampleMgmtErrorList_customFormat() {
 	//
 	// [[]] failed.
 }
+
+type plainMarshalerError struct{}
+
+func (e plainMarshalerError) Error() string { return "plain" }
+
+func (e plainMarshalerError) MarshalJSON() ([]byte, error) {
+	return []byte(`{}`), nil
+}
+
+// A Formattable error that is not one of the MgmtError types
+type customFormattableError struct {
+	tag, severity string
+}
+
+func (e customFormattableError) Error() string       { return "custom" }
+func (e customFormattableError) GetMessage() string  { return "custom message" }
+func (e customFormattableError) GetPath() string     { return "/custom/path" }
+func (e customFormattableError) GetSeverity() string { return e.severity }
+func (e customFormattableError) GetTag() string      { return e.tag }
+func (e customFormattableError) GetAppTag() string   { return "custom-app-tag" }
+func (e customFormattableError) GetType() string     { return "protocol" }
+func (e customFormattableError) GetInfo() MgmtErrorInfo {
+	return MgmtErrorInfo{
+		*NewMgmtErrorInfoTag(VyattaNamespace, "custom", "info"),
+	}
+}
+
+func TestMgmtErrorListAppendFormattable(t *testing.T) {
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(
+		customFormattableError{tag: "in-use", severity: "warning"},
+		customFormattableError{})
+
+	exp := newMgmtError()
+	exp.Typ = "protocol"
+	exp.Tag = "in-use"
+	exp.Severity = "warning"
+	exp.AppTag = "custom-app-tag"
+	exp.Path = "/custom/path"
+	exp.Message = "custom message"
+	exp.Info = customFormattableError{}.GetInfo()
+
+	inUse, ok := errs.Errors()[0].(*InUseProtocolError)
+	if !ok {
+		t.Fatalf("Converted to %T", errs.Errors()[0])
+	}
+	cmpMgmtError(t, exp, inUse.MgmtError)
+
+	// Missing tag and severity are those of operation-failed
+	exp.Tag = "operation-failed"
+	exp.Severity = "error"
+	opFailed, ok := errs.Errors()[1].(*OperationFailedProtocolError)
+	if !ok {
+		t.Fatalf("Converted to %T", errs.Errors()[1])
+	}
+	cmpMgmtError(t, exp, opFailed.MgmtError)
+}
+
+func TestMgmtErrorListCounts(t *testing.T) {
+	warn := NewMustViolationError()
+	warn.Severity = yang_severity_warning.String()
+
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(NewOperationFailedApplicationError(),
+		NewMustViolationError(),
+		warn,
+		NewAccessDeniedProtocolError(),
+		fmt.Errorf("This is not a MgmtError error"),
+		plainMarshalerError{})
+
+	expTags := map[string]int{
+		"operation-failed": 4,
+		"access-denied":    1,
+		"unknown":          1,
+	}
+	if tags := errs.CountByTag(); !reflect.DeepEqual(expTags, tags) {
+		t.Errorf("Unexpected tag counts\n  expect: %v\n  got:    %v",
+			expTags, tags)
+	}
+
+	expSeverities := map[string]int{
+		"error":   4,
+		"warning": 1,
+		"unknown": 1,
+	}
+	if sevs := errs.CountBySeverity(); !reflect.DeepEqual(expSeverities, sevs) {
+		t.Errorf("Unexpected severity counts\n  expect: %v\n  got:    %v",
+			expSeverities, sevs)
+	}
+}
+
+func TestMgmtErrorListContains(t *testing.T) {
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(NewMustViolationError(), plainMarshalerError{})
+
+	if !errs.ContainsTag("operation-failed") {
+		t.Errorf("operation-failed not found")
+	}
+	if errs.ContainsTag("access-denied") {
+		t.Errorf("Unexpected access-denied found")
+	}
+	isPlain := func(err error) bool {
+		_, ok := err.(plainMarshalerError)
+		return ok
+	}
+	if !errs.Contains(isPlain) {
+		t.Errorf("Non-Formattable error not passed to predicate")
+	}
+	if (MgmtErrorList{}).Contains(isPlain) {
+		t.Errorf("Unexpected match in empty list")
+	}
+}
+
+func TestMgmtErrorListMgmtErrors(t *testing.T) {
+	mustErr := NewMustViolationError()
+	inUse := NewInUseProtocolError()
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(mustErr, plainMarshalerError{}, inUse.MgmtError)
+
+	plain := NewOperationFailedApplicationError().MgmtError
+	plain.Message = "plain"
+	exp := []*MgmtError{mustErr.MgmtError, plain, inUse.MgmtError}
+	if got := errs.MgmtErrors(); !reflect.DeepEqual(exp, got) {
+		t.Errorf("Unexpected MgmtErrors")
+		t.Logf("Expected: %# v", pretty.Formatter(exp))
+		t.Logf("Result:   %# v", pretty.Formatter(got))
+	}
+	if got := errs.MgmtErrors()[0]; got != mustErr.MgmtError {
+		t.Errorf("Embedded MgmtError not returned")
+	}
+	if got := (MgmtErrorList{}).MgmtErrors(); len(got) != 0 {
+		t.Errorf("Unexpected MgmtErrors from empty list: %v", got)
+	}
+}
+
+// As returned by errors.Join
+type joinedError []error
+
+func (e joinedError) Error() string   { return "joined" }
+func (e joinedError) Unwrap() []error { return e }
+
+func TestFromJoined(t *testing.T) {
+	mustErr := NewMustViolationError()
+	inUse := NewInUseProtocolError()
+	var nested MgmtErrorList
+	nested.MgmtErrorListAppend(NewAccessDeniedApplicationError(),
+		NewDataMissingError())
+
+	joined := joinedError{
+		mustErr,
+		nil,
+		joinedError{errors.New("plain"), inUse},
+		nested,
+	}
+	plain := NewOperationFailedApplicationError()
+	plain.Message = "plain"
+	var exp MgmtErrorList
+	exp.MgmtErrorListAppend(mustErr, plain, inUse,
+		NewAccessDeniedApplicationError(), NewDataMissingError())
+	if got := FromJoined(joined); !reflect.DeepEqual(exp, got) {
+		t.Errorf("Unexpected list from joined errors")
+		t.Logf("Expected: %# v", pretty.Formatter(exp))
+		t.Logf("Result:   %# v", pretty.Formatter(got))
+	}
+
+	exp = MgmtErrorList{}
+	exp.MgmtErrorListAppend(inUse)
+	if got := FromJoined(inUse); !reflect.DeepEqual(exp, got) {
+		t.Errorf("Unexpected list from single error: %v", got)
+	}
+	if got := FromJoined(nil).Errors(); len(got) != 0 {
+		t.Errorf("Unexpected list from nil error: %v", got)
+	}
+}
+
+// As returned by errors.Join, which is comparable
+type joinedPtrError struct{ errs []error }
+
+func (e *joinedPtrError) Error() string   { return "joined" }
+func (e *joinedPtrError) Unwrap() []error { return e.errs }
+
+func TestFromJoinedCycle(t *testing.T) {
+	inUse := NewInUseProtocolError()
+	cycle := joinedError{inUse, nil, nil}
+	cycle[1] = cycle
+	cycle[2] = joinedError{cycle}
+	ptrCycle := &joinedPtrError{}
+	ptrCycle.errs = []error{ptrCycle, inUse, &joinedPtrError{
+		[]error{ptrCycle}}}
+
+	for _, err := range []error{cycle, ptrCycle} {
+		done := make(chan MgmtErrorList)
+		go func() { done <- FromJoined(err) }()
+		select {
+		case list := <-done:
+			if list.Len() != 1 || list.Errors()[0] != error(inUse) {
+				t.Errorf("Unexpected errors from cycle: %v", list)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("FromJoined did not terminate on a cycle")
+		}
+	}
+}
+
+func TestFromJoinedDeep(t *testing.T) {
+	const depth = 2000
+	var err error
+	for i := 0; i < depth; i++ {
+		err = &joinedPtrError{[]error{NewInUseProtocolError(), err}}
+	}
+	if list := FromJoined(err); list.Len() != depth {
+		t.Errorf("Unexpected number of errors: %d", list.Len())
+	}
+}
+
+func TestSingletonList(t *testing.T) {
+	inUse := NewInUseProtocolError()
+	var exp MgmtErrorList
+	exp.MgmtErrorListAppend(inUse)
+	if got := SingletonList(inUse); !reflect.DeepEqual(exp, got) {
+		t.Errorf("Unexpected list from single error: %v", got)
+	}
+
+	plain := NewOperationFailedApplicationError()
+	plain.Message = "plain"
+	exp = MgmtErrorList{}
+	exp.MgmtErrorListAppend(plain)
+	got := SingletonList(errors.New("plain"))
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("Unexpected list from plain error")
+		t.Logf("Expected: %# v", pretty.Formatter(exp))
+		t.Logf("Result:   %# v", pretty.Formatter(got))
+	}
+
+	var list MgmtErrorList
+	list.MgmtErrorListAppend(inUse, NewDataMissingError())
+	if got := SingletonList(list); !reflect.DeepEqual(list, got) {
+		t.Errorf("List not returned unchanged: %v", got)
+	}
+	if got := SingletonList(&list); !reflect.DeepEqual(list, got) {
+		t.Errorf("List pointer not returned unchanged: %v", got)
+	}
+	if got := SingletonList(nil); got.Len() != 0 {
+		t.Errorf("Unexpected list from nil error: %v", got)
+	}
+}
+
+func TestMgmtErrorListGroupByPath(t *testing.T) {
+	mustErr := NewMustViolationError()
+	mustErr.Path = "/a"
+	mustErr.Message = "must failed"
+	badElem := NewBadElementApplicationError("x")
+	badElem.Path = "/a"
+	other := NewInUseApplicationError()
+	other.Path = "/b"
+	noPath := NewAccessDeniedApplicationError()
+	tooMany := NewTooManyElementsErrorWithCount("/a", 3, 2)
+
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(noPath, mustErr, other, badElem,
+		plainMarshalerError{}, tooMany)
+
+	merged := NewMustViolationError().MgmtError
+	merged.Path = "/a"
+	merged.Message = "must failed; " + msg_nc_bad_element +
+		"; list has 3 entries, maximum 2"
+	merged.Info = MgmtErrorInfo{
+		*NewMgmtErrorInfoTag(VyattaNamespace, "message", "must failed"),
+		*NewMgmtErrorInfoTag(VyattaNamespace, "message",
+			msg_nc_bad_element),
+		*NewMgmtErrorInfoTag(VyattaNamespace, "message",
+			"list has 3 entries, maximum 2"),
+		*NewMgmtErrorInfoTag(VyattaNamespace, "app-tag",
+			"too-many-elements"),
+		*NewMgmtErrorInfoTag("", "bad-element", "x"),
+	}
+	merged.Info = append(merged.Info, tooMany.Info...)
+
+	var exp MgmtErrorList
+	exp.MgmtErrorListAppend(noPath)
+	exp.appendError(merged)
+	exp.MgmtErrorListAppend(other, plainMarshalerError{})
+	if got := errs.GroupByPath(); !reflect.DeepEqual(exp, got) {
+		t.Errorf("Unexpected grouped list")
+		t.Logf("Expected: %# v", pretty.Formatter(exp))
+		t.Logf("Result:   %# v", pretty.Formatter(got))
+	}
+	if mustErr.Message != "must failed" || len(mustErr.Info) != 0 {
+		t.Errorf("Grouping modified the original error: %v", mustErr)
+	}
+}
+
+func TestMgmtErrorListGroupByPathMergeInfo(t *testing.T) {
+	first := NewOperationFailedApplicationError().MgmtError
+	first.Path = "/a"
+	first.Message = "failed"
+	first.Info = MgmtErrorInfo{
+		*NewMgmtErrorInfoTag(VyattaNamespace, "held-by", ""),
+		*NewMgmtErrorInfoTag(VyattaNamespace, "code", "7"),
+	}
+	second := NewOperationFailedApplicationError().MgmtError
+	second.Path = "/a"
+	second.Message = "failed"
+	second.Info = MgmtErrorInfo{
+		*NewMgmtErrorInfoTag(VyattaNamespace, "held-by", "session 42"),
+		*NewMgmtErrorInfoTag(VyattaNamespace, "code", "7"),
+		*NewMgmtErrorInfoTag("urn:debug", "trace", "frame 1"),
+	}
+
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(first, second)
+	grouped := errs.GroupByPath().Errors()
+	if len(grouped) != 1 {
+		t.Fatalf("Unexpected grouped errors: %v", grouped)
+	}
+	// One message per error, then the union of the errors' info
+	exp := MgmtErrorInfo{
+		*NewMgmtErrorInfoTag(VyattaNamespace, "message", "failed"),
+		*NewMgmtErrorInfoTag(VyattaNamespace, "message", "failed"),
+		*NewMgmtErrorInfoTag(VyattaNamespace, "held-by", "session 42"),
+		*NewMgmtErrorInfoTag(VyattaNamespace, "code", "7"),
+		*NewMgmtErrorInfoTag("urn:debug", "trace", "frame 1"),
+	}
+	if got := asMgmtError(grouped[0]).Info; !reflect.DeepEqual(exp, got) {
+		t.Errorf("Unexpected merged info")
+		t.Logf("Expected: %# v", pretty.Formatter(exp))
+		t.Logf("Result:   %# v", pretty.Formatter(got))
+	}
+}
+
+func TestMergeInfo(t *testing.T) {
+	err := NewNonUniqueError([]string{"/a/b"})
+	err.MergeInfo(NewNonUniqueError([]string{"/a/b", "/a/c"}).MgmtError)
+	if paths := err.NonUniquePaths(); !reflect.DeepEqual(paths,
+		[]string{"/a/b", "/a/c"}) {
+		t.Errorf("Unexpected merged paths: %v", paths)
+	}
+	err.MergeInfo(nil)
+	if len(err.Info) != 2 {
+		t.Errorf("Unexpected info after merging nil: %v", err.Info)
+	}
+}
+
+func TestMgmtErrorListGroupByPathAppTags(t *testing.T) {
+	gen := func(appTag string) *MgmtError {
+		err := NewOperationFailedApplicationError().MgmtError
+		err.Path = "/a"
+		err.AppTag = appTag
+		return err
+	}
+	appTagInfo := func(merged *MgmtError) []string {
+		var tags []string
+		for _, t := range merged.Info {
+			if t.XMLName.Local == "app-tag" {
+				tags = append(tags, t.Value)
+			}
+		}
+		return tags
+	}
+
+	tests := []struct {
+		name     string
+		priority []string
+		appTags  []string
+		keep     string
+		others   []string
+	}{
+		{"no conflict", nil, []string{"x", "x", ""}, "x", nil},
+		{"first kept", nil, []string{"x", "y", "z", "y"}, "x",
+			[]string{"y", "z"}},
+		{"first empty", nil, []string{"", "y", "x"}, "y", []string{"x"}},
+		{"priority", []string{"z", "y"}, []string{"x", "y", "z"}, "z",
+			[]string{"x", "y"}},
+		{"priority over empty", []string{"y"}, []string{"", "y"}, "y",
+			nil},
+	}
+	defer func() { AppTagPriority = nil }()
+	for _, test := range tests {
+		AppTagPriority = test.priority
+		var errs MgmtErrorList
+		for _, appTag := range test.appTags {
+			errs.MgmtErrorListAppend(gen(appTag))
+		}
+		grouped := errs.GroupByPath().Errors()
+		if len(grouped) != 1 {
+			t.Errorf("%s: unexpected grouped errors: %v",
+				test.name, grouped)
+			continue
+		}
+		merged := grouped[0].(MgmtErrorRef).getMgmtError()
+		if merged.AppTag != test.keep {
+			t.Errorf("%s: kept app-tag %q, expected %q",
+				test.name, merged.AppTag, test.keep)
+		}
+		if got := appTagInfo(merged); !reflect.DeepEqual(got, test.others) {
+			t.Errorf("%s: app-tag info %v, expected %v",
+				test.name, got, test.others)
+		}
+	}
+}
+
+func TestMgmtErrorListClientFacing(t *testing.T) {
+	internal := NewOperationFailedApplicationError()
+	internal.Message = "assertion failed: len(keys) > 0"
+	internal.MarkInternal()
+	mustErr := NewMustViolationError()
+
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(mustErr, internal, errors.New("plain"))
+
+	var exp MgmtErrorList
+	exp.MgmtErrorListAppend(mustErr, errors.New("plain"))
+	if got := errs.ClientFacing(); !reflect.DeepEqual(exp, got) {
+		t.Errorf("Unexpected client facing list")
+		t.Logf("Expected: %# v", pretty.Formatter(exp))
+		t.Logf("Result:   %# v", pretty.Formatter(got))
+	}
+	if errs.Len() != 3 {
+		t.Errorf("Original list modified: %v", errs)
+	}
+
+	// The marker survives encoding, eg on the way to the logs
+	marshal, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	var unmarshal MgmtErrorList
+	if err := json.Unmarshal(marshal, &unmarshal); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if !asMgmtError(unmarshal.Errors()[1]).IsInternal() {
+		t.Errorf("Internal marker lost by JSON round trip")
+	}
+	if asMgmtError(unmarshal.Errors()[0]).IsInternal() {
+		t.Errorf("Unmarked error is internal")
+	}
+	if got := unmarshal.ClientFacing().Len(); got != 2 {
+		t.Errorf("Unexpected client facing errors after round trip: %d",
+			got)
+	}
+}
+
+func TestParseDBusErrorList(t *testing.T) {
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(NewMustViolationError(),
+		NewNonUniqueError([]string{"/a/b", "/a/c"}))
+
+	name, body := errs.DBusError()
+	parsed, err := ParseDBusErrorList(name, body)
+	if err != nil {
+		t.Fatalf("Unexpected parse error: %v", err)
+	}
+	if !reflect.DeepEqual(errs.Errors(), parsed.Errors()) {
+		t.Errorf("Unexpected list parsed from DBus error")
+		t.Logf("Expected: %# v", pretty.Formatter(errs))
+		t.Logf("Result:   %# v", pretty.Formatter(parsed))
+	}
+
+	marshal, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	parsed, err = ParseDBusErrorList(name, []interface{}{string(marshal)})
+	if err != nil {
+		t.Fatalf("Unexpected error parsing JSON body: %v", err)
+	}
+	if !reflect.DeepEqual(errs.Errors(), parsed.Errors()) {
+		t.Errorf("Unexpected list parsed from JSON body")
+		t.Logf("Expected: %# v", pretty.Formatter(errs))
+		t.Logf("Result:   %# v", pretty.Formatter(parsed))
+	}
+
+	inUse := NewInUseProtocolError()
+	name, body = inUse.DBusError()
+	parsed, err = ParseDBusErrorList(name, body)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing single error: %v", err)
+	}
+	got := parsed.Errors()
+	if len(got) != 1 || !reflect.DeepEqual(got[0], inUse) {
+		t.Errorf("Unexpected errors parsed from single error: %# v",
+			pretty.Formatter(got))
+	}
+
+	for _, test := range []struct {
+		name string
+		body []interface{}
+	}{
+		{"org.freedesktop.DBus.Error.Failed", []interface{}{"failed"}},
+		{dbusErrorListName, nil},
+		{dbusErrorListName, []interface{}{42}},
+		{dbusErrorListName, []interface{}{"not json"}},
+		{"com.vyatta.rpcerror.protocol", []interface{}{&errs}},
+	} {
+		if _, err := ParseDBusErrorList(test.name, test.body); err == nil {
+			t.Errorf("Unexpected success parsing %s: %v", test.name,
+				test.body)
+		}
+	}
+}
+
+func TestMgmtErrorListTruncate(t *testing.T) {
+	var errs MgmtErrorList
+	for i := uint(1); i <= 5; i++ {
+		errs.MgmtErrorListAppend(genTestMgmtError(i))
+	}
+
+	overflow := NewOperationFailedApplicationError()
+	overflow.Message = "3 more errors omitted"
+	var exp MgmtErrorList
+	exp.MgmtErrorListAppend(genTestMgmtError(1), genTestMgmtError(2),
+		overflow)
+	truncated := errs.Truncate(2)
+	if !reflect.DeepEqual(exp, truncated) {
+		t.Errorf("Unexpected truncated list")
+		t.Logf("Expected: %# v", pretty.Formatter(exp))
+		t.Logf("Result:   %# v", pretty.Formatter(truncated))
+	}
+	if _, err := xml.Marshal(truncated); err != nil {
+		t.Errorf("Marshal truncated list error: %v", err)
+	}
+	if len(errs.Errors()) != 5 {
+		t.Errorf("Original list modified: %v", errs)
+	}
+
+	if got := errs.Truncate(5); !reflect.DeepEqual(errs, got) {
+		t.Errorf("Unexpected truncation of list within limit: %v", got)
+	}
+	got := errs.Truncate(0).Errors()
+	if len(got) != 1 || got[0].(Formattable).GetMessage() !=
+		"5 more errors omitted" {
+		t.Errorf("Unexpected truncation to no errors: %v", got)
+	}
+}
+
+func TestMgmtErrorListPrimary(t *testing.T) {
+	warning := NewInUseApplicationError()
+	warning.Severity = "warning"
+	mustErr := NewMustViolationError()
+	accessDenied := NewAccessDeniedApplicationError()
+
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(warning, mustErr, plainMarshalerError{},
+		accessDenied)
+
+	if got := errs.Primary(); got != mustErr {
+		t.Errorf("Unexpected primary error: %v", got)
+	}
+
+	if got := errs.Primary("access-denied",
+		"operation-failed"); got != accessDenied {
+		t.Errorf("Unexpected primary error by tag: %v", got)
+	}
+
+	var warnings MgmtErrorList
+	warnings.MgmtErrorListAppend(warning)
+	if got := warnings.Primary(); got != warning {
+		t.Errorf("Unexpected primary warning: %v", got)
+	}
+	if got := (MgmtErrorList{}).Primary(); got != nil {
+		t.Errorf("Unexpected primary error of empty list: %v", got)
+	}
+}
+
+func TestMgmtErrorListNotification(t *testing.T) {
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(genTestMgmtError(1), genTestMgmtError(2))
+
+	eventTime := time.Date(2020, time.March, 4, 10, 20, 30, 0, time.UTC)
+	marshal, err := errs.MarshalNotification(eventTime)
+	if err != nil {
+		t.Fatalf("Marshal notification error: %v\n", err)
+	}
+
+	expected := `<notification xmlns="` + notification_namespace + `">` +
+		`<eventTime>2020-03-04T10:20:30Z</eventTime>` +
+		`<error-list xmlns="` + VyattaNamespace + `">` +
+		strings.Replace(strings.Replace(genMgmtErrorListXml(2),
+			"\n", "", -1), "\t", "", -1) +
+		`</error-list></notification>`
+	if string(marshal) != expected {
+		t.Error("Unexpected notification marshal result")
+		t.Logf("Expected: %s", expected)
+		t.Logf("Marshal:  %s", marshal)
+	}
+}
+
+func TestMgmtErrorListJoinedError(t *testing.T) {
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(genTestMgmtError(1), genTestMgmtError(2))
+
+	const exp = "Error: /path/1: Message 1; Error: /path/2: Message 2"
+	if got := errs.JoinedError("; "); got != exp {
+		t.Errorf("Unexpected joined error\n  expect: %s\n  got:    %s",
+			exp, got)
+	}
+
+	ErrorListSeparator = "; "
+	defer func() { ErrorListSeparator = "\n" }()
+	if got := errs.Error(); got != exp {
+		t.Errorf("Unexpected error\n  expect: %s\n  got:    %s", exp, got)
+	}
+}
+
+// reorderErrors sorts the errors in a list by their error strings, in
+// reverse.
+func reorderErrors(errs *MgmtErrorList) {
+	errs.Sort(func(a, b error) bool { return a.Error() > b.Error() })
+}
+
+// sliceError is an error that cannot be compared, so can only be
+// identified by its position in a list.
+type sliceError []string
+
+func (e sliceError) Error() string { return strings.Join(e, ", ") }
+
+func (e sliceError) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]string(e))
+}
+
+func TestMgmtErrorListSortBySequence(t *testing.T) {
+	var errs MgmtErrorList
+	first := genTestMgmtError(1)
+	errs.MgmtErrorListAppend(first, genTestMgmtError(2), first)
+	errs.MgmtErrorListAppend(genTestMgmtError(3))
+	internal := NewOperationFailedApplicationError()
+	internal.MarkInternal()
+	errs.MgmtErrorListAppend(internal, sliceError{"a"},
+		genTestMgmtError(4))
+	expected := errs.Errors()
+
+	// The slice returned by Errors is a copy
+	copied := errs.Errors()
+	copied[0], copied[1] = copied[1], copied[0]
+	if !reflect.DeepEqual(expected, errs.Errors()) {
+		t.Fatalf("List reordered through Errors")
+	}
+
+	reorderErrors(&errs)
+	if reflect.DeepEqual(expected, errs.Errors()) {
+		t.Fatalf("List not reordered")
+	}
+	errs.SortBySequence()
+	if !reflect.DeepEqual(expected, errs.Errors()) {
+		t.Errorf("Detection order not restored")
+		t.Logf("Expected: %# v", pretty.Formatter(expected))
+		t.Logf("Result:   %# v", pretty.Formatter(errs.Errors()))
+	}
+
+	// Derived lists keep the sequence of their errors
+	reorderErrors(&errs)
+	clientFacing := errs.ClientFacing()
+	clientFacing.SortBySequence()
+	expClientFacing := append(append([]error{}, expected[:4]...),
+		expected[5:]...)
+	if !reflect.DeepEqual(expClientFacing, clientFacing.Errors()) {
+		t.Errorf("Detection order not restored for client facing list")
+		t.Logf("Expected: %# v", pretty.Formatter(expClientFacing))
+		t.Logf("Result:   %# v", pretty.Formatter(clientFacing.Errors()))
+	}
+
+	truncated := clientFacing.Truncate(3)
+	reorderErrors(&truncated)
+	truncated.SortBySequence()
+	got := truncated.Errors()
+	if len(got) != 4 || !reflect.DeepEqual(expected[:3], got[:3]) ||
+		!strings.Contains(got[3].Error(), "omitted") {
+		t.Errorf("Detection order not restored for truncated list")
+		t.Logf("Result:   %# v", pretty.Formatter(got))
+	}
+
+	// A derived list in detection order is the same as one built by
+	// appending its errors in that order
+	var exp MgmtErrorList
+	exp.MgmtErrorListAppend(expClientFacing...)
+	if !reflect.DeepEqual(exp, clientFacing) {
+		t.Errorf("Unexpected client facing list")
+		t.Logf("Expected: %# v", pretty.Formatter(exp))
+		t.Logf("Result:   %# v", pretty.Formatter(clientFacing))
+	}
+}
+
+func TestMgmtErrorListReset(t *testing.T) {
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(genTestMgmtError(1), genTestMgmtError(2))
+	errs.MgmtErrorListAppend(genTestMgmtError(3))
+	capacity := cap(errs.entries)
+
+	errs.Reset()
+	if errs.Len() != 0 {
+		t.Errorf("Unexpected length after reset: %d", errs.Len())
+	}
+	if cap(errs.entries) != capacity {
+		t.Errorf("Capacity not retained: expected %d, got %d",
+			capacity, cap(errs.entries))
+	}
+
+	// Reused list is numbered afresh for SortBySequence
+	errs.MgmtErrorListAppend(genTestMgmtError(4), genTestMgmtError(5))
+	expected := errs.Errors()
+	reorderErrors(&errs)
+	errs.SortBySequence()
+	if errs.Len() != 2 || !reflect.DeepEqual(expected, errs.Errors()) {
+		t.Errorf("Unexpected errors after reuse")
+		t.Logf("Expected: %# v", pretty.Formatter(expected))
+		t.Logf("Result:   %# v", pretty.Formatter(errs.Errors()))
+	}
+}
+
+func TestMgmtErrorListMarshalXMLPathPrefixes(t *testing.T) {
+	err := NewDataMissingError()
+	err.Path = "/interfaces/interface"
+	var list MgmtErrorList
+	list.MgmtErrorListAppend(err)
+
+	ErrorPathPrefixes = map[string]string{"interfaces": "if"}
+	defer func() { ErrorPathPrefixes = nil }()
+	out, e := xml.Marshal(list)
+	if e != nil {
+		t.Fatalf("Unexpected marshal error: %v", e)
+	}
+	const expected = "<error-path>/if:interfaces/interface</error-path>"
+	if !strings.Contains(string(out), expected) {
+		t.Errorf("Expected %s in:\n%s", expected, out)
+	}
+	if err.Path != "/interfaces/interface" {
+		t.Errorf("Path of error changed to %s", err.Path)
+	}
+}
@@ -21,16 +21,35 @@ type ncerrseverity uint
 
 const (
 	nc_severity_error ncerrseverity = iota
+	nc_severity_warning
 )
 
 var ncerrseveritymap = map[ncerrseverity]string{
-	nc_severity_error: "error",
+	nc_severity_error:   "error",
+	nc_severity_warning: "warning",
 }
 
 func (s ncerrseverity) String() string {
 	return ncerrseveritymap[s]
 }
 
+// Severity is an error-severity, as defined by RFC6241.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+func (s Severity) valid() bool {
+	for _, sev := range ncerrseveritymap {
+		if string(s) == sev {
+			return true
+		}
+	}
+	return false
+}
+
 type ncerrtag uint
 
 // RFC6241 Apdx A
@@ -303,25 +322,160 @@ func init() {
 	}
 }
 
-func getNetconfError(err *MgmtError) error {
+// netconfErrorCreator returns the function creating the NETCONF error
+// type matching err, if there is one.
+func netconfErrorCreator(err *MgmtError) (interface{}, bool) {
+	if obsoleteErrorTag(err) {
+		return createPartialOperationError, true
+	}
 	tag, ok := ncerrtagmap[err.Tag]
 	if !ok {
-		return nil
+		return nil, false
 	}
 
 	ncErrTag, ok := ncErrTable[tag]
 	if !ok {
-		return nil
+		return nil, false
 	}
 
 	errTypeId, ok := errtypemap[err.Typ]
 	if !ok {
+		return nil, false
+	}
+
+	fn, ok := ncErrTag.typ[errTypeId]
+	return fn, ok
+}
+
+func getNetconfError(err *MgmtError) error {
+	fn, ok := netconfErrorCreator(err)
+	if !ok {
+		return nil
+	}
+	return callCreate(fn, err)
+}
+
+// ToNetconf returns a copy of the error as a plain NETCONF error, for
+// clients that do not understand YANG or Vyatta specific errors. The
+// app-tag is removed and, if the error-tag is not defined by RFC6241 for
+// the error-type, it is replaced by operation-failed. An error-type for
+// which operation-failed is not defined becomes an application error.
+func (e *MgmtError) ToNetconf() *MgmtError {
+	c := *e
+	c.AppTag = ""
+	c.emptyAppTag = false
+	if _, ok := netconfErrorCreator(&c); ok {
+		return &c
+	}
+	c.Tag = operation_failed.String()
+	if _, ok := netconfErrorCreator(&c); !ok {
+		c.Typ = application.String()
+	}
+	return &c
+}
+
+// checkTagType returns an error if typ is not a valid error-type for
+// the NETCONF error-tag tag.
+func checkTagType(tag, typ string) error {
+	typeId, ok := errtypemap[typ]
+	if !ok {
+		return invalid_error_type
+	}
+	tagId, ok := ncerrtagmap[tag]
+	if !ok {
+		return invalid_error_tag
+	}
+	if _, ok := ncErrTable[tagId].typ[typeId]; !ok {
+		return invalid_error_tag_type
+	}
+	return nil
+}
+
+// CheckConsistency returns an error if the error-type and error-tag are
+// not a combination defined by RFC6241 Appendix A, or registered as a
+// Vyatta error, eg after Typ has been modified.
+func (e *MgmtError) CheckConsistency() error {
+	if _, ok := vyattaErrorCreator(e); ok {
 		return nil
 	}
+	if err := checkTagType(e.Tag, e.Typ); err != nil {
+		return fmt.Errorf("%v: error-type %q with error-tag %q",
+			err, e.Typ, e.Tag)
+	}
+	return nil
+}
+
+// FillDefaults sets the error-severity and error-message, if they are
+// empty, to the defaults for the error-type, error-tag and
+// error-app-tag, as the constructors do, eg for an error built by
+// assigning its fields. The Vyatta and YANG defaults are used for an
+// app-tag they define, otherwise those of RFC6241.
+//
+// An error is returned, and the error left unchanged, if the error-tag
+// is not defined for the error-type.
+func (e *MgmtError) FillDefaults() error {
+	severity, msg, ok := tableDefaults(e)
+	if !ok {
+		return fmt.Errorf("%v: error-type %q with error-tag %q",
+			invalid_error_tag_type, e.Typ, e.Tag)
+	}
+	if e.XMLName.Local == "" {
+		e.setXMLName()
+	}
+	if e.Severity == "" {
+		e.Severity = severity
+	}
+	if e.Message == "" {
+		e.Message = msg
+	}
+	return nil
+}
+
+// tableDefaults returns the severity and message of the error in
+// vyErrTable, yangErrTable or ncErrTable, in that order of precedence.
+func tableDefaults(e *MgmtError) (string, string, bool) {
+	if e.Typ == application.String() {
+		if tag, ok := vyErrTagMap[e.Tag]; ok {
+			vyErr := vyErrTable[tag]
+			apptag, ok := vyErrAppTagMap[e.AppTag]
+			if _, defined := vyErr.apptag[apptag]; ok && defined {
+				return vyErr.severity.String(), vyErr.msg, true
+			}
+		}
+		if tag, ok := errtagmap[e.Tag]; ok {
+			yErr := yangErrTable[tag]
+			apptag, ok := yerrapptagmap[e.AppTag]
+			if _, defined := yErr.apptag[apptag]; ok && defined {
+				return yErr.severity.String(), yErr.msg, true
+			}
+		}
+	}
+	if checkTagType(e.Tag, e.Typ) != nil {
+		return "", "", false
+	}
+	ncErr := ncErrTable[ncerrtagmap[e.Tag]]
+	return ncErr.severity.String(), ncErr.msg, true
+}
+
+// SetType changes the error-type, provided the result is consistent, as
+// checked by CheckConsistency.
+func (e *MgmtError) SetType(typ string) error {
+	c := *e
+	c.Typ = typ
+	if err := c.CheckConsistency(); err != nil {
+		return err
+	}
+	e.Typ = typ
+	return nil
+}
 
-	if fn, ok := ncErrTag.typ[errTypeId]; ok {
-		return callCreate(fn, err)
+// SetSeverity changes the error-severity, returning an error if it is
+// not one of those defined by RFC6241.
+func (e *MgmtError) SetSeverity(s Severity) error {
+	if !s.valid() {
+		return fmt.Errorf("invalid error-severity: %s", s)
 	}
+	e.Severity = string(s)
 	return nil
 }
 
@@ -333,6 +487,10 @@ const (
 	bad_element_info
 	bad_namespace_info
 	session_id_info
+	// Obsolete, only used by partial-operation
+	ok_element_info
+	err_element_info
+	noop_element_info
 )
 
 var ncErrInfoIdMap = map[ncErrInfoId]string{
@@ -340,6 +498,9 @@ var ncErrInfoIdMap = map[ncErrInfoId]string{
 	bad_element_info:   "bad-element",
 	bad_namespace_info: "bad-namespace",
 	session_id_info:    "session-id",
+	ok_element_info:    "ok-element",
+	err_element_info:   "err-element",
+	noop_element_info:  "noop-element",
 }
 
 func (i ncErrInfoId) String() string {
@@ -364,7 +525,12 @@ func (e *MgmtError) setNcError(tag ncerrtag, typ, apptag, path string, info *Mgm
 		return invalid_error_type
 	}
 	if _, ok := ncErrTag.typ[errTypeId]; !ok {
-		return invalid_error_tag_type
+		// A Vyatta error may be registered for a type that RFC6241
+		// does not define for the tag, eg TransportBadAttrError.
+		key := vyRegisteredKey{typ: typ, tag: tag.String(), apptag: apptag}
+		if _, ok := vyRegisteredErrors[key]; !ok {
+			return invalid_error_tag_type
+		}
 	}
 	e.Tag = tag.String()
 	e.Typ = typ
@@ -378,7 +544,19 @@ func (e *MgmtError) setNcError(tag ncerrtag, typ, apptag, path string, info *Mgm
 	return nil
 }
 
+var defaultAppTags = map[string]string{}
+
+// RegisterDefaultAppTag sets the error-app-tag given to NETCONF errors
+// with error-tag tag when they are constructed without one, so that it
+// is applied uniformly. There are no defaults unless registered.
+func RegisterDefaultAppTag(tag, appTag string) {
+	defaultAppTags[tag] = appTag
+}
+
 func newNcError(tag ncerrtag, typ, apptag, path string, info *MgmtErrorInfo) *MgmtError {
+	if apptag == "" {
+		apptag = defaultAppTags[tag.String()]
+	}
 	e := newMgmtError()
 	if err := e.setNcError(tag, typ, apptag, path, info); err != nil {
 		panic(err)
@@ -386,28 +564,36 @@ func newNcError(tag ncerrtag, typ, apptag, path string, info *MgmtErrorInfo) *Mg
 	return e
 }
 
-func newAttrError(tag ncerrtag, typ, badAttr, badElem string) *MgmtError {
+// newAttrError creates an attribute error. The bad-element info is
+// omitted if the element is not known, ie badElem is empty, rather
+// than being encoded as an empty element.
+func newAttrError(tag ncerrtag, typ, apptag, badAttr, badElem string) *MgmtError {
 	info := MgmtErrorInfo{
 		MgmtErrorInfoTag{
 			XMLName: xml.Name{
+				Space: netconf_namespace,
 				Local: bad_attribute_info.String(),
 			},
 			Value: badAttr,
 		},
-		MgmtErrorInfoTag{
+	}
+	if badElem != "" {
+		info = append(info, MgmtErrorInfoTag{
 			XMLName: xml.Name{
+				Space: netconf_namespace,
 				Local: bad_element_info.String(),
 			},
 			Value: badElem,
-		},
+		})
 	}
-	return newNcError(tag, typ, "", "", &info)
+	return newNcError(tag, typ, apptag, "", &info)
 }
 
 func newElemError(tag ncerrtag, typ, badElem string) *MgmtError {
 	info := MgmtErrorInfo{
 		MgmtErrorInfoTag{
 			XMLName: xml.Name{
+				Space: netconf_namespace,
 				Local: bad_element_info.String(),
 			},
 			Value: badElem,
@@ -429,6 +615,16 @@ func (e *InUseProtocolError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *InUseProtocolError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *InUseProtocolError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *InUseProtocolError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -453,6 +649,16 @@ func (e *InUseApplicationError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *InUseApplicationError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *InUseApplicationError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *InUseApplicationError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -481,6 +687,16 @@ func (e *InvalidValueProtocolError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *InvalidValueProtocolError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *InvalidValueProtocolError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *InvalidValueProtocolError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -496,6 +712,14 @@ func NewInvalidValueProtocolError() *InvalidValueProtocolError {
 	return createInvalidValueProtocolError(newInvalidValueError(protocol.String()))
 }
 
+// As NewInvalidValueProtocolError, additionally recording the invalid
+// value with SetOffendingValue.
+func NewInvalidValueProtocolErrorWithValue(value string) *InvalidValueProtocolError {
+	err := NewInvalidValueProtocolError()
+	err.SetOffendingValue(value)
+	return err
+}
+
 type InvalidValueApplicationError struct {
 	*MgmtError
 }
@@ -505,6 +729,16 @@ func (e *InvalidValueApplicationError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *InvalidValueApplicationError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *InvalidValueApplicationError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *InvalidValueApplicationError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -520,6 +754,14 @@ func NewInvalidValueApplicationError() *InvalidValueApplicationError {
 	return createInvalidValueApplicationError(newInvalidValueError(application.String()))
 }
 
+// As NewInvalidValueApplicationError, additionally recording the invalid
+// value with SetOffendingValue.
+func NewInvalidValueApplicationErrorWithValue(value string) *InvalidValueApplicationError {
+	err := NewInvalidValueApplicationError()
+	err.SetOffendingValue(value)
+	return err
+}
+
 func newTooBigError(typ string) *MgmtError {
 	return newNcError(too_big, typ, "", "", nil)
 }
@@ -533,6 +775,16 @@ func (e *TooBigTransportError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *TooBigTransportError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *TooBigTransportError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *TooBigTransportError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -558,6 +810,16 @@ func (e *TooBigRpcError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *TooBigRpcError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *TooBigRpcError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *TooBigRpcError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -583,6 +845,16 @@ func (e *TooBigProtocolError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *TooBigProtocolError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *TooBigProtocolError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *TooBigProtocolError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -608,6 +880,16 @@ func (e *TooBigApplicationError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *TooBigApplicationError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *TooBigApplicationError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *TooBigApplicationError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -625,7 +907,7 @@ func NewTooBigApplicationError() *TooBigApplicationError {
 }
 
 func newMissingAttrError(typ, badAttr, badElem string) *MgmtError {
-	return newAttrError(missing_attribute, typ, badAttr, badElem)
+	return newAttrError(missing_attribute, typ, "", badAttr, badElem)
 }
 
 type MissingAttrRpcError struct {
@@ -637,6 +919,16 @@ func (e *MissingAttrRpcError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *MissingAttrRpcError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *MissingAttrRpcError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *MissingAttrRpcError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -665,6 +957,16 @@ func (e *MissingAttrProtocolError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *MissingAttrProtocolError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *MissingAttrProtocolError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *MissingAttrProtocolError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -693,6 +995,16 @@ func (e *MissingAttrApplicationError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *MissingAttrApplicationError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *MissingAttrApplicationError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *MissingAttrApplicationError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -713,7 +1025,7 @@ func NewMissingAttrApplicationError(badAttr, badElem string) *MissingAttrApplica
 }
 
 func newBadAttrError(typ, badAttr, badElem string) *MgmtError {
-	return newAttrError(bad_attribute, typ, badAttr, badElem)
+	return newAttrError(bad_attribute, typ, "", badAttr, badElem)
 }
 
 type BadAttrRpcError struct {
@@ -725,6 +1037,16 @@ func (e *BadAttrRpcError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *BadAttrRpcError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *BadAttrRpcError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *BadAttrRpcError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -744,6 +1066,14 @@ func NewBadAttrRpcError(badAttr, badElem string) *BadAttrRpcError {
 	return createBadAttrRpcError(newBadAttrError(rpc.String(), badAttr, badElem))
 }
 
+// As NewBadAttrRpcError, additionally recording the bad value of the
+// attribute with SetOffendingValue.
+func NewBadAttrRpcErrorWithValue(badAttr, badElem, value string) *BadAttrRpcError {
+	err := NewBadAttrRpcError(badAttr, badElem)
+	err.SetOffendingValue(value)
+	return err
+}
+
 type BadAttrProtocolError struct {
 	*MgmtError
 }
@@ -753,6 +1083,16 @@ func (e *BadAttrProtocolError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *BadAttrProtocolError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *BadAttrProtocolError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *BadAttrProtocolError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -772,6 +1112,14 @@ func NewBadAttrProtocolError(badAttr, badElem string) *BadAttrProtocolError {
 	return createBadAttrProtocolError(newBadAttrError(protocol.String(), badAttr, badElem))
 }
 
+// As NewBadAttrProtocolError, additionally recording the bad value of the
+// attribute with SetOffendingValue.
+func NewBadAttrProtocolErrorWithValue(badAttr, badElem, value string) *BadAttrProtocolError {
+	err := NewBadAttrProtocolError(badAttr, badElem)
+	err.SetOffendingValue(value)
+	return err
+}
+
 type BadAttrApplicationError struct {
 	*MgmtError
 }
@@ -781,6 +1129,16 @@ func (e *BadAttrApplicationError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *BadAttrApplicationError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *BadAttrApplicationError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *BadAttrApplicationError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -800,8 +1158,28 @@ func NewBadAttrApplicationError(badAttr, badElem string) *BadAttrApplicationErro
 	return createBadAttrApplicationError(newBadAttrError(application.String(), badAttr, badElem))
 }
 
+// As NewBadAttrApplicationError, additionally recording the bad value of the
+// attribute with SetOffendingValue.
+func NewBadAttrApplicationErrorWithValue(badAttr, badElem, value string) *BadAttrApplicationError {
+	err := NewBadAttrApplicationError(badAttr, badElem)
+	err.SetOffendingValue(value)
+	return err
+}
+
 func newUnknownAttrError(typ, badAttr, badElem string) *MgmtError {
-	return newAttrError(unknown_attribute, typ, badAttr, badElem)
+	return newAttrError(unknown_attribute, typ, "", badAttr, badElem)
+}
+
+// unknownAttrMessage names the unexpected attribute and its element in
+// the message of the unknown attribute errors. If either is not known,
+// fall back to the generic message.
+func unknownAttrMessage(e *MgmtError) string {
+	attr := e.Info.FindMgmtErrorTag("", bad_attribute_info.String())
+	elem := badElement(e)
+	if attr == "" || elem == "" {
+		return e.Message
+	}
+	return formatMessage(UnknownAttributeMessage, attr, elem)
 }
 
 type UnknownAttrRpcError struct {
@@ -813,10 +1191,28 @@ func (e *UnknownAttrRpcError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *UnknownAttrRpcError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *UnknownAttrRpcError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *UnknownAttrRpcError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
 
+func (e *UnknownAttrRpcError) GetMessage() string {
+	return e.decorate(e.message())
+}
+
+func (e *UnknownAttrRpcError) message() string {
+	return unknownAttrMessage(e.MgmtError)
+}
+
 func createUnknownAttrRpcError(err *MgmtError) *UnknownAttrRpcError {
 	return &UnknownAttrRpcError{
 		MgmtError: err,
@@ -840,10 +1236,28 @@ func (e *UnknownAttrProtocolError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *UnknownAttrProtocolError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *UnknownAttrProtocolError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *UnknownAttrProtocolError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
 
+func (e *UnknownAttrProtocolError) GetMessage() string {
+	return e.decorate(e.message())
+}
+
+func (e *UnknownAttrProtocolError) message() string {
+	return unknownAttrMessage(e.MgmtError)
+}
+
 func createUnknownAttrProtocolError(err *MgmtError) *UnknownAttrProtocolError {
 	return &UnknownAttrProtocolError{
 		MgmtError: err,
@@ -867,10 +1281,28 @@ func (e *UnknownAttrApplicationError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *UnknownAttrApplicationError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *UnknownAttrApplicationError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *UnknownAttrApplicationError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
 
+func (e *UnknownAttrApplicationError) GetMessage() string {
+	return e.decorate(e.message())
+}
+
+func (e *UnknownAttrApplicationError) message() string {
+	return unknownAttrMessage(e.MgmtError)
+}
+
 func createUnknownAttrApplicationError(err *MgmtError) *UnknownAttrApplicationError {
 	return &UnknownAttrApplicationError{
 		MgmtError: err,
@@ -889,21 +1321,60 @@ func newMissingElemError(typ, badElem string) *MgmtError {
 	return newElemError(missing_element, typ, badElem)
 }
 
-func missingElemErrorString(e *MgmtError) string {
+// elemSetErrorString formats the element errors as reported by the CLI
+// when setting configuration, eg:
+//
+//	Configuration path: interfaces dataplane [dp0s99] is not valid
+func elemSetErrorString(e *MgmtError) string {
+	path := e.Path
+	if elem := badElement(e); elem != "" {
+		path = strings.TrimSuffix(path, "/") + "/" + elem
+	}
+	elems := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	return configPathString(elems, "")
+}
+
+// configPathString formats a configuration path as reported by the CLI
+// when setting configuration, followed by reason, or by "is not valid"
+// if there is no reason.
+func configPathString(elems []string, reason string) string {
+	if reason == "" {
+		reason = "is not valid"
+	}
+	return fmt.Sprintf("Configuration path: %s %s", ErrPathCLI(elems), reason)
+}
+
+// badElement returns the bad-element info of the error, looked up by
+// name as a peer may send it after other info tags, or "" if it has
+// none.
+func badElement(e *MgmtError) string {
+	return e.Info.FindMgmtErrorTag("", bad_element_info.String())
+}
+
+// elemErrorString formats the element errors with the bad element
+// appended to the path. An error without the bad-element info (eg, one
+// unmarshalled from a peer that omits it) is formatted as a plain
+// MgmtError.
+func elemErrorString(e *MgmtError) string {
+	elem := badElement(e)
+	if elem == "" {
+		return e.Error()
+	}
+
 	var b bytes.Buffer
 
-	b.WriteString(strings.Title(e.Severity))
+	b.WriteString(titledSeverity(e.GetSeverity()))
 	b.WriteString(error_msg_separator)
 
 	if e.Path != "" {
 		b.WriteString(e.Path)
 	}
 	b.WriteByte('/')
-	b.WriteString(e.Info[0].Value)
+	b.WriteString(elem)
 
-	if e.Message != "" {
+	if msg := e.decorate(e.Message); msg != "" {
 		b.WriteString(error_msg_separator)
-		b.WriteString(e.Message)
+		b.WriteString(msg)
 	}
 
 	return b.String()
@@ -918,12 +1389,26 @@ func (e *MissingElementProtocolError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *MissingElementProtocolError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *MissingElementProtocolError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *MissingElementProtocolError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
 
 func (e *MissingElementProtocolError) Error() string {
-	return missingElemErrorString(e.MgmtError)
+	return elemErrorString(e.MgmtError)
+}
+
+func (e *MissingElementProtocolError) SetErrorString() string {
+	return elemSetErrorString(e.MgmtError)
 }
 
 func createMissingElementProtocolError(err *MgmtError) *MissingElementProtocolError {
@@ -948,12 +1433,26 @@ func (e *MissingElementApplicationError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *MissingElementApplicationError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *MissingElementApplicationError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *MissingElementApplicationError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
 
 func (e *MissingElementApplicationError) Error() string {
-	return missingElemErrorString(e.MgmtError)
+	return elemErrorString(e.MgmtError)
+}
+
+func (e *MissingElementApplicationError) SetErrorString() string {
+	return elemSetErrorString(e.MgmtError)
 }
 
 func createMissingElementApplicationError(err *MgmtError) *MissingElementApplicationError {
@@ -982,6 +1481,16 @@ func (e *BadElementProtocolError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *BadElementProtocolError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *BadElementProtocolError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *BadElementProtocolError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -999,6 +1508,14 @@ func NewBadElementProtocolError(badElem string) *BadElementProtocolError {
 	return createBadElementProtocolError(newBadElemError(protocol.String(), badElem))
 }
 
+// As NewBadElementProtocolError, additionally recording the bad value of
+// the element with SetOffendingValue.
+func NewBadElementProtocolErrorWithValue(badElem, value string) *BadElementProtocolError {
+	err := NewBadElementProtocolError(badElem)
+	err.SetOffendingValue(value)
+	return err
+}
+
 type BadElementApplicationError struct {
 	*MgmtError
 }
@@ -1008,6 +1525,16 @@ func (e *BadElementApplicationError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *BadElementApplicationError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *BadElementApplicationError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *BadElementApplicationError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -1025,35 +1552,33 @@ func NewBadElementApplicationError(badElem string) *BadElementApplicationError {
 	return createBadElementApplicationError(newBadElemError(application.String(), badElem))
 }
 
-func newUnknownElemError(typ, badElem string) *MgmtError {
-	return newElemError(unknown_element, typ, badElem)
+// As NewBadElementApplicationError, additionally recording the bad value of
+// the element with SetOffendingValue.
+func NewBadElementApplicationErrorWithValue(badElem, value string) *BadElementApplicationError {
+	err := NewBadElementApplicationError(badElem)
+	err.SetOffendingValue(value)
+	return err
 }
 
-func unknownElemErrorString(e *MgmtError) string {
-	// TODO - all identical error functions should be using common code!!!
-	var b bytes.Buffer
-
-	b.WriteString(strings.Title(e.Severity))
-	b.WriteString(error_msg_separator)
-
-	if e.Path != "" {
-		b.WriteString(e.Path)
-	}
-	b.WriteByte('/')
-	b.WriteString(e.Info[0].Value)
-	if e.Message != "" {
-		b.WriteString(error_msg_separator)
-		b.WriteString(e.Message)
-	}
-
-	return b.String()
+func newUnknownElemError(typ, badElem string) *MgmtError {
+	return newElemError(unknown_element, typ, badElem)
 }
 
 type UnknownElementProtocolError struct {
 	*MgmtError
 }
 
+// ErrPath formats path for display, as ErrPathRPC.
 func ErrPath(path []string) string {
+	return ErrPathRPC(path)
+}
+
+// ErrPathRPC formats path for an error returned over RPC. Elements are
+// space separated with the last element in [], and are shown in their
+// encoded form, eg:
+//
+//	interfaces dataplane dp0s1 address [10.0.0.1%2F24]
+func ErrPathRPC(path []string) string {
 	if len(path) < 2 {
 		return fmt.Sprintf("%s", path)
 	}
@@ -1061,9 +1586,55 @@ func ErrPath(path []string) string {
 	return fmt.Sprintf("%s [%s]", strings.Join(path, " "), val)
 }
 
+// ErrPathCLI formats path for an error displayed by the CLI. This is
+// the same layout as ErrPathRPC, but elements are shown as the user
+// would have typed them, eg:
+//
+//	interfaces dataplane dp0s1 address [10.0.0.1/24]
+//
+// Paths longer than ErrPathCLIElideThreshold elements have their middle
+// elided, eg:
+//
+//	a b ... y [z]
+func ErrPathCLI(path []string) string {
+	cliPath := make([]string, len(path))
+	for i, elem := range path {
+		cliPath[i] = strings.Replace(elem, "%2F", "/", -1)
+	}
+	if ErrPathCLIElideThreshold > 0 && len(cliPath) > ErrPathCLIElideThreshold {
+		elided := append([]string{}, cliPath[:errPathCLIKeep]...)
+		elided = append(elided, "...")
+		cliPath = append(elided, cliPath[len(cliPath)-errPathCLIKeep:]...)
+	}
+	return ErrPathRPC(cliPath)
+}
+
+// ErrPathCLIElideThreshold is the number of path elements above which
+// ErrPathCLI elides the middle of the path. Zero disables elision.
+var ErrPathCLIElideThreshold = 32
+
+// Number of elements kept at each end of an elided path
+const errPathCLIKeep = 2
+
+// unknownElemMessage builds the "<path> is not valid" message for the
+// unknown element errors. If the bad element is not known, fall back to
+// the generic message.
+func unknownElemMessage(e *MgmtError) string {
+	elem := badElement(e)
+	if elem == "" {
+		return e.Message
+	}
+	path := strings.TrimSuffix(e.Path, "/") + "/" + elem
+	return formatMessage(UnknownElementMessage,
+		ErrPath(pathutil.Makepath(path)))
+}
+
 func (uepe *UnknownElementProtocolError) GetMessage() string {
-	return fmt.Sprintf("%s is not valid",
-		ErrPath(pathutil.Makepath(uepe.Path+"/"+uepe.Info[0].Value)))
+	return uepe.decorate(uepe.message())
+}
+
+func (uepe *UnknownElementProtocolError) message() string {
+	return unknownElemMessage(uepe.MgmtError)
 }
 
 func (e *UnknownElementProtocolError) UnmarshalJSON(value []byte) error {
@@ -1071,12 +1642,26 @@ func (e *UnknownElementProtocolError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *UnknownElementProtocolError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *UnknownElementProtocolError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *UnknownElementProtocolError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
 
 func (e *UnknownElementProtocolError) Error() string {
-	return unknownElemErrorString(e.MgmtError)
+	return elemErrorString(e.MgmtError)
+}
+
+func (e *UnknownElementProtocolError) SetErrorString() string {
+	return elemSetErrorString(e.MgmtError)
 }
 
 func createUnknownElementProtocolError(err *MgmtError) *UnknownElementProtocolError {
@@ -1097,8 +1682,11 @@ type UnknownElementApplicationError struct {
 }
 
 func (ueae *UnknownElementApplicationError) GetMessage() string {
-	return fmt.Sprintf("%s is not valid",
-		ErrPath(pathutil.Makepath(ueae.Path+"/"+ueae.Info[0].Value)))
+	return ueae.decorate(ueae.message())
+}
+
+func (ueae *UnknownElementApplicationError) message() string {
+	return unknownElemMessage(ueae.MgmtError)
 }
 
 func (e *UnknownElementApplicationError) UnmarshalJSON(value []byte) error {
@@ -1106,12 +1694,26 @@ func (e *UnknownElementApplicationError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *UnknownElementApplicationError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *UnknownElementApplicationError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *UnknownElementApplicationError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
 
 func (e *UnknownElementApplicationError) Error() string {
-	return unknownElemErrorString(e.MgmtError)
+	return elemErrorString(e.MgmtError)
+}
+
+func (e *UnknownElementApplicationError) SetErrorString() string {
+	return elemSetErrorString(e.MgmtError)
 }
 
 func createUnknownElementApplicationError(err *MgmtError) *UnknownElementApplicationError {
@@ -1131,12 +1733,14 @@ func newUnknownNamespaceError(typ, badElem, badNS string) *MgmtError {
 	info := MgmtErrorInfo{
 		MgmtErrorInfoTag{
 			XMLName: xml.Name{
+				Space: netconf_namespace,
 				Local: bad_element_info.String(),
 			},
 			Value: badElem,
 		},
 		MgmtErrorInfoTag{
 			XMLName: xml.Name{
+				Space: netconf_namespace,
 				Local: bad_namespace_info.String(),
 			},
 			Value: badNS,
@@ -1154,6 +1758,16 @@ func (e *UnknownNamespaceProtocolError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *UnknownNamespaceProtocolError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *UnknownNamespaceProtocolError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *UnknownNamespaceProtocolError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -1181,6 +1795,16 @@ func (e *UnknownNamespaceApplicationError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *UnknownNamespaceApplicationError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *UnknownNamespaceApplicationError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *UnknownNamespaceApplicationError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -1212,6 +1836,16 @@ func (e *AccessDeniedProtocolError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *AccessDeniedProtocolError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *AccessDeniedProtocolError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *AccessDeniedProtocolError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -1236,6 +1870,16 @@ func (e *AccessDeniedApplicationError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *AccessDeniedApplicationError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *AccessDeniedApplicationError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *AccessDeniedApplicationError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -1260,6 +1904,16 @@ func (e *LockDeniedError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *LockDeniedError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *LockDeniedError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *LockDeniedError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -1278,6 +1932,7 @@ func NewLockDeniedError(sess string) *LockDeniedError {
 	info := MgmtErrorInfo{
 		MgmtErrorInfoTag{
 			XMLName: xml.Name{
+				Space: netconf_namespace,
 				Local: session_id_info.String(),
 			},
 			Value: sess,
@@ -1286,6 +1941,29 @@ func NewLockDeniedError(sess string) *LockDeniedError {
 	return createLockDeniedError(newNcError(lock_denied, protocol.String(), "", "", &info))
 }
 
+// As NewLockDeniedError, for a lock held by a non-NETCONF entity, such
+// as a CLI process.
+//
+// desc describes the entity holding the lock.
+func NewLockDeniedByProcess(desc string) *LockDeniedError {
+	err := NewLockDeniedError("0")
+	err.Info = append(err.Info, *NewMgmtErrorInfoTag(VyattaNamespace,
+		held_by_info.String(), desc))
+	return err
+}
+
+func (e *LockDeniedError) GetMessage() string {
+	return e.decorate(e.message())
+}
+
+func (e *LockDeniedError) message() string {
+	holder := e.Info.FindMgmtErrorTag(VyattaNamespace, held_by_info.String())
+	if holder == "" {
+		return e.Message
+	}
+	return formatMessage(LockHeldByMessage, holder)
+}
+
 func newResourceDeniedError(typ string) *MgmtError {
 	return newNcError(resource_denied, typ, "", "", nil)
 }
@@ -1299,6 +1977,16 @@ func (e *ResourceDeniedTransportError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *ResourceDeniedTransportError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *ResourceDeniedTransportError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *ResourceDeniedTransportError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -1324,6 +2012,16 @@ func (e *ResourceDeniedRpcError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *ResourceDeniedRpcError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *ResourceDeniedRpcError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *ResourceDeniedRpcError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -1349,6 +2047,16 @@ func (e *ResourceDeniedProtocolError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *ResourceDeniedProtocolError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *ResourceDeniedProtocolError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *ResourceDeniedProtocolError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -1374,6 +2082,16 @@ func (e *ResourceDeniedApplicationError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *ResourceDeniedApplicationError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *ResourceDeniedApplicationError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *ResourceDeniedApplicationError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -1403,6 +2121,16 @@ func (e *RollbackFailedProtocolError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *RollbackFailedProtocolError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *RollbackFailedProtocolError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *RollbackFailedProtocolError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -1429,6 +2157,16 @@ func (e *RollbackFailedApplicationError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *RollbackFailedApplicationError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *RollbackFailedApplicationError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *RollbackFailedApplicationError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -1455,6 +2193,16 @@ func (e *DataExistsError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *DataExistsError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *DataExistsError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *DataExistsError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -1471,6 +2219,23 @@ func NewDataExistsError() *DataExistsError {
 	return createDataExistsError(newNcError(data_exists, application.String(), "", "", nil))
 }
 
+// As NewDataExistsError, for the operation, eg "create", attempted on
+// the data at path. The operation is recorded in an info tag.
+func NewDataExistsFor(op, path string) *DataExistsError {
+	err := NewDataExistsError()
+	err.Path = path
+	err.setVyattaInfo(operation_info, op)
+	return err
+}
+
+func (e *DataExistsError) GetMessage() string {
+	return e.decorate(e.message())
+}
+
+func (e *DataExistsError) message() string {
+	return dataOperationMessage(e.MgmtError, DataExistsMessage)
+}
+
 type DataMissingError struct {
 	*MgmtError
 }
@@ -1480,6 +2245,16 @@ func (e *DataMissingError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *DataMissingError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *DataMissingError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *DataMissingError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -1496,10 +2271,55 @@ func NewDataMissingError() *DataMissingError {
 	return createDataMissingError(newNcError(data_missing, application.String(), "", "", nil))
 }
 
+// As NewDataMissingError, for the operation, eg "delete" or "replace",
+// attempted on the data at path. The operation is recorded in an info
+// tag.
+func NewDataMissingFor(op, path string) *DataMissingError {
+	err := NewDataMissingError()
+	err.Path = path
+	err.setVyattaInfo(operation_info, op)
+	return err
+}
+
+func (e *DataMissingError) GetMessage() string {
+	return e.decorate(e.message())
+}
+
+func (e *DataMissingError) message() string {
+	return dataOperationMessage(e.MgmtError, DataMissingMessage)
+}
+
+// dataOperationMessage names the operation attempted and the path, if
+// the operation is known, otherwise it is the generic message.
+func dataOperationMessage(e *MgmtError, kind MessageKind) string {
+	op := e.Info.FindMgmtErrorTag(VyattaNamespace, operation_info.String())
+	if op == "" {
+		return e.Message
+	}
+	return formatMessage(kind, op, e.Path)
+}
+
 func newOperationNotSupportedError(typ string) *MgmtError {
 	return newNcError(operation_not_supported, typ, "", "", nil)
 }
 
+// newOperationNotSupportedErrorFor records the operation in an info tag.
+func newOperationNotSupportedErrorFor(typ, op string) *MgmtError {
+	err := newOperationNotSupportedError(typ)
+	err.setVyattaInfo(operation_info, op)
+	return err
+}
+
+// operationNotSupportedMessage names the operation, if it is known,
+// otherwise it is the generic message.
+func operationNotSupportedMessage(e *MgmtError) string {
+	op := e.Info.FindMgmtErrorTag(VyattaNamespace, operation_info.String())
+	if op == "" {
+		return e.Message
+	}
+	return formatMessage(OperationNotSupportedMessage, op)
+}
+
 type OperationNotSupportedProtocolError struct {
 	*MgmtError
 }
@@ -1509,6 +2329,16 @@ func (e *OperationNotSupportedProtocolError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *OperationNotSupportedProtocolError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *OperationNotSupportedProtocolError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *OperationNotSupportedProtocolError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -1525,6 +2355,20 @@ func NewOperationNotSupportedProtocolError() *OperationNotSupportedProtocolError
 	return createOperationNotSupportedProtocolError(newOperationNotSupportedError(protocol.String()))
 }
 
+// As NewOperationNotSupportedProtocolError, naming the operation, eg
+// "copy-config".
+func NewOperationNotSupportedProtocolErrorFor(op string) *OperationNotSupportedProtocolError {
+	return createOperationNotSupportedProtocolError(newOperationNotSupportedErrorFor(protocol.String(), op))
+}
+
+func (e *OperationNotSupportedProtocolError) GetMessage() string {
+	return e.decorate(e.message())
+}
+
+func (e *OperationNotSupportedProtocolError) message() string {
+	return operationNotSupportedMessage(e.MgmtError)
+}
+
 type OperationNotSupportedApplicationError struct {
 	*MgmtError
 }
@@ -1534,6 +2378,16 @@ func (e *OperationNotSupportedApplicationError) UnmarshalJSON(value []byte) erro
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *OperationNotSupportedApplicationError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *OperationNotSupportedApplicationError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *OperationNotSupportedApplicationError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -1550,6 +2404,20 @@ func NewOperationNotSupportedApplicationError() *OperationNotSupportedApplicatio
 	return createOperationNotSupportedApplicationError(newOperationNotSupportedError(application.String()))
 }
 
+// As NewOperationNotSupportedApplicationError, naming the operation, eg
+// a YANG action.
+func NewOperationNotSupportedApplicationErrorFor(op string) *OperationNotSupportedApplicationError {
+	return createOperationNotSupportedApplicationError(newOperationNotSupportedErrorFor(application.String(), op))
+}
+
+func (e *OperationNotSupportedApplicationError) GetMessage() string {
+	return e.decorate(e.message())
+}
+
+func (e *OperationNotSupportedApplicationError) message() string {
+	return operationNotSupportedMessage(e.MgmtError)
+}
+
 func newOperationFailedError(typ string) *MgmtError {
 	return newNcError(operation_failed, typ, "", "", nil)
 }
@@ -1563,6 +2431,16 @@ func (e *OperationFailedProtocolError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *OperationFailedProtocolError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *OperationFailedProtocolError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *OperationFailedProtocolError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -1589,6 +2467,16 @@ func (e *OperationFailedApplicationError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *OperationFailedApplicationError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *OperationFailedApplicationError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *OperationFailedApplicationError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -1615,6 +2503,16 @@ func (e *OperationFailedRpcError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *OperationFailedRpcError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *OperationFailedRpcError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *OperationFailedRpcError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -1641,6 +2539,16 @@ func (e *MalformedMessageError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *MalformedMessageError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *MalformedMessageError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *MalformedMessageError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -1658,3 +2566,74 @@ func createMalformedMessageError(err *MgmtError) *MalformedMessageError {
 func NewMalformedMessageError() *MalformedMessageError {
 	return createMalformedMessageError(newNcError(malformed_message, "rpc", "", "", nil))
 }
+
+// AllowObsoleteTags enables the obsolete partial-operation error-tag,
+// defined by RFC4741 but not by RFC6241, so that a client talking to an
+// old server can interpret a partial-operation result. Such errors are
+// only ever parsed, never created. It is false by default.
+var AllowObsoleteTags bool
+
+const partial_operation_tag = "partial-operation"
+
+func obsoleteErrorTag(err *MgmtError) bool {
+	return AllowObsoleteTags && err.Tag == partial_operation_tag &&
+		err.Typ == application.String()
+}
+
+type PartialOperationError struct {
+	*MgmtError
+}
+
+func (e *PartialOperationError) UnmarshalJSON(value []byte) error {
+	e.MgmtError = newMgmtError()
+	return json.Unmarshal(value, e.MgmtError)
+}
+
+func (e *PartialOperationError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *PartialOperationError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
+func (e *PartialOperationError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return enc.Encode(e.MgmtError)
+}
+
+func createPartialOperationError(err *MgmtError) *PartialOperationError {
+	return &PartialOperationError{
+		MgmtError: err,
+	}
+}
+
+// OKElements returns the elements for which the operation was
+// completed, from the ok-element info.
+func (e *PartialOperationError) OKElements() []string {
+	return e.infoValues(ok_element_info)
+}
+
+// ErrElements returns the elements for which the operation failed, from
+// the err-element info.
+func (e *PartialOperationError) ErrElements() []string {
+	return e.infoValues(err_element_info)
+}
+
+// NoopElements returns the elements for which the operation was not
+// attempted, from the noop-element info.
+func (e *PartialOperationError) NoopElements() []string {
+	return e.infoValues(noop_element_info)
+}
+
+func (e *PartialOperationError) infoValues(id ncErrInfoId) []string {
+	var values []string
+	for _, t := range e.Info {
+		if t.XMLName.Local == id.String() &&
+			sameInfoNamespace(t.XMLName.Space, netconf_namespace) {
+			values = append(values, t.Value)
+		}
+	}
+	return values
+}
@@ -11,7 +11,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"html"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/kr/pretty"
 )
 
 const (
@@ -294,6 +298,24 @@ func TestBadAttrProtocolError(t *testing.T) {
 	verifyXmlMarshal(t, ncerr, genBadAttrXml(protocol.String(), bad_attr_value, bad_elem_value))
 }
 
+func TestBadAttrErrorNoElement(t *testing.T) {
+	ncerr := NewBadAttrProtocolError(bad_attr_value, "")
+	expected := strings.Replace(
+		genBadAttrXml(protocol.String(), bad_attr_value, ""),
+		"\n\t\t<bad-element></bad-element>", "", 1)
+	verifyXmlMarshal(t, ncerr, expected)
+
+	marshal, err := json.Marshal(ncerr)
+	if err != nil {
+		t.Fatalf("Marshal BadAttrProtocolError error: %v\n", err)
+	}
+	unmarshal := BadAttrProtocolError{}
+	if err := json.Unmarshal(marshal, &unmarshal); err != nil {
+		t.Fatalf("Unmarshal BadAttrProtocolError error: %v\n", err)
+	}
+	cmpMgmtError(t, ncerr.MgmtError, unmarshal.MgmtError)
+}
+
 func TestBadAttrApplicationError(t *testing.T) {
 	ncerr := NewBadAttrApplicationError(bad_attr_value, bad_elem_value)
 	marshal, err := json.MarshalIndent(ncerr, "", "\t")
@@ -375,6 +397,98 @@ func TestUnknownAttrApplicationError(t *testing.T) {
 	verifyXmlMarshal(t, ncerr, genUnknownAttrXml(application.String(), bad_attr_value, bad_elem_value))
 }
 
+func TestUnknownAttrErrorMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"rpc", NewUnknownAttrRpcError("foo", "bar"),
+			"unexpected attribute 'foo' on element 'bar'"},
+		{"protocol", NewUnknownAttrProtocolError("foo", "bar"),
+			"unexpected attribute 'foo' on element 'bar'"},
+		{"application", NewUnknownAttrApplicationError("foo", "bar"),
+			"unexpected attribute 'foo' on element 'bar'"},
+		{"no element", NewUnknownAttrApplicationError("foo", ""),
+			msg_nc_unknown_attribute},
+		{"no info", &UnknownAttrApplicationError{
+			newNcError(unknown_attribute, application.String(), "", "",
+				nil)},
+			msg_nc_unknown_attribute},
+	}
+	for _, test := range tests {
+		msg := test.err.(Formattable).GetMessage()
+		if msg != test.expected {
+			t.Errorf("%s: unexpected message\n  expect: %s\n  got:    %s",
+				test.name, test.expected, msg)
+		}
+	}
+}
+
+func TestDataOperationErrorMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"exists", NewDataExistsFor("create", "/interfaces/dataplane/dp0s3"),
+			"cannot create /interfaces/dataplane/dp0s3: data already exists"},
+		{"missing delete", NewDataMissingFor("delete", "/system/ntp"),
+			"cannot delete /system/ntp: data does not exist"},
+		{"missing replace", NewDataMissingFor("replace", "/system/ntp"),
+			"cannot replace /system/ntp: data does not exist"},
+		{"exists generic", NewDataExistsError(), msg_nc_data_exists},
+		{"missing generic", NewDataMissingError(), msg_nc_data_missing},
+	}
+	for _, test := range tests {
+		msg := test.err.(Formattable).GetMessage()
+		if msg != test.expected {
+			t.Errorf("%s: unexpected message\n  expect: %s\n  got:    %s",
+				test.name, test.expected, msg)
+		}
+	}
+
+	err := NewDataMissingFor("delete", "/system/ntp")
+	if op := err.Info.FindMgmtErrorTag(VyattaNamespace,
+		operation_info.String()); op != "delete" {
+		t.Errorf("Unexpected operation info: %s", op)
+	}
+	if err.GetPath() != "/system/ntp" {
+		t.Errorf("Unexpected path: %s", err.GetPath())
+	}
+}
+
+func TestOperationNotSupportedErrorMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"protocol", NewOperationNotSupportedProtocolErrorFor("copy-config"),
+			"operation 'copy-config' is not supported"},
+		{"application",
+			NewOperationNotSupportedApplicationErrorFor("get-schema"),
+			"operation 'get-schema' is not supported"},
+		{"protocol generic", NewOperationNotSupportedProtocolError(),
+			msg_nc_operation_not_supported},
+		{"application generic", NewOperationNotSupportedApplicationError(),
+			msg_nc_operation_not_supported},
+	}
+	for _, test := range tests {
+		msg := test.err.(Formattable).GetMessage()
+		if msg != test.expected {
+			t.Errorf("%s: unexpected message\n  expect: %s\n  got:    %s",
+				test.name, test.expected, msg)
+		}
+	}
+
+	err := NewOperationNotSupportedProtocolErrorFor("copy-config")
+	if op := err.Info.FindMgmtErrorTag(VyattaNamespace,
+		operation_info.String()); op != "copy-config" {
+		t.Errorf("Unexpected operation info: %s", op)
+	}
+}
+
 func genMissingElementXml(typ, bad_elem_value string) string {
 	return `<rpc-error xmlns="` + netconf_namespace + `">
 	<error-type>` + html.EscapeString(typ) + `</error-type>
@@ -513,12 +627,215 @@ func TestUnknownElementApplicationError(t *testing.T) {
 	verifyXmlMarshal(t, ncerr, genUnknownElementXml(application.String(), bad_elem_value))
 }
 
+func TestUnknownElementGetMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		err  Formattable
+		exp  string
+	}{
+		{
+			name: "protocol with path",
+			err: func() Formattable {
+				e := NewUnknownElementProtocolError("baz")
+				e.Path = "/foo/bar"
+				return e
+			}(),
+			exp: "foo bar [baz] is not valid",
+		},
+		{
+			name: "protocol without path",
+			err:  NewUnknownElementProtocolError("baz"),
+			exp:  "[baz] is not valid",
+		},
+		{
+			name: "application without path",
+			err:  NewUnknownElementApplicationError("baz"),
+			exp:  "[baz] is not valid",
+		},
+		{
+			name: "protocol without info",
+			err: func() Formattable {
+				e := NewUnknownElementProtocolError("baz")
+				e.Info = nil
+				return e
+			}(),
+			exp: msg_nc_unknown_element,
+		},
+		{
+			name: "application without info",
+			err: func() Formattable {
+				e := NewUnknownElementApplicationError("baz")
+				e.Path = "/foo/bar"
+				e.Info = MgmtErrorInfo{}
+				return e
+			}(),
+			exp: msg_nc_unknown_element,
+		},
+		{
+			name: "bad-element after other info",
+			err: func() Formattable {
+				e := NewUnknownElementProtocolError("baz")
+				e.Path = "/foo/bar"
+				e.Info = append(MgmtErrorInfo{*NewMgmtErrorInfoTag(
+					VyattaNamespace, "limit", "1024")}, e.Info...)
+				return e
+			}(),
+			exp: "foo bar [baz] is not valid",
+		},
+	}
+
+	for _, test := range tests {
+		if msg := test.err.GetMessage(); msg != test.exp {
+			t.Errorf("%s: unexpected message\n  expect: %s\n  got:    %s",
+				test.name, test.exp, msg)
+		}
+	}
+}
+
+func ExampleErrPathRPC() {
+	path := []string{"interfaces", "dataplane", "dp0s1", "address", "10.0.0.1%2F24"}
+	fmt.Println(ErrPathRPC(path))
+	fmt.Println(ErrPathRPC(path[:1]))
+
+	// Output:
+	// interfaces dataplane dp0s1 address [10.0.0.1%2F24]
+	// [interfaces]
+}
+
+func ExampleErrPathCLI() {
+	path := []string{"interfaces", "dataplane", "dp0s1", "address", "10.0.0.1%2F24"}
+	fmt.Println(ErrPathCLI(path))
+	fmt.Println(ErrPathCLI(path[:1]))
+
+	// Output:
+	// interfaces dataplane dp0s1 address [10.0.0.1/24]
+	// [interfaces]
+}
+
+func TestErrPathCLIElide(t *testing.T) {
+	defer func(threshold int) {
+		ErrPathCLIElideThreshold = threshold
+	}(ErrPathCLIElideThreshold)
+	ErrPathCLIElideThreshold = 5
+
+	path := []string{"a", "b", "c", "d", "e", "f"}
+	tests := []struct {
+		path     []string
+		expected string
+	}{
+		{path[:5], "a b c d [e]"},
+		{path, "a b ... e [f]"},
+	}
+	for _, test := range tests {
+		if got := ErrPathCLI(test.path); got != test.expected {
+			t.Errorf("Unexpected CLI path\n  expect: %s\n  got:    %s",
+				test.expected, got)
+		}
+	}
+	if got := ErrPathRPC(path); got != "a b c d e [f]" {
+		t.Errorf("Unexpected elision of RPC path: %s", got)
+	}
+
+	ErrPathCLIElideThreshold = 0
+	if got := ErrPathCLI(path); got != "a b c d e [f]" {
+		t.Errorf("Unexpected elision when disabled: %s", got)
+	}
+}
+
+// An error from a malformed peer may be missing its bad-element info.
+// Formatting it must not panic.
+func TestElementErrorsWithoutInfo(t *testing.T) {
+	const stripped = `{"error-list":[
+		{"error-type":"protocol","error-tag":"unknown-element",
+		 "error-severity":"error","error-path":"/foo"},
+		{"error-type":"application","error-tag":"unknown-element",
+		 "error-severity":"error"},
+		{"error-type":"protocol","error-tag":"missing-element",
+		 "error-severity":"error","error-path":"/foo"},
+		{"error-type":"application","error-tag":"missing-element",
+		 "error-severity":"error"}]}`
+
+	var errs MgmtErrorList
+	if err := json.Unmarshal([]byte(stripped), &errs); err != nil {
+		t.Fatalf("Unmarshal error: %v\n", err)
+	}
+
+	expect := []string{
+		"Error: /foo: ",
+		"Error: ",
+		"Error: /foo: ",
+		"Error: ",
+	}
+	if len(errs.Errors()) != len(expect) {
+		t.Fatalf("Expected %d errors, got %d",
+			len(expect), len(errs.Errors()))
+	}
+	for i, err := range errs.Errors() {
+		if msg := err.Error(); msg != expect[i] {
+			t.Errorf("Unexpected error string for %T\n"+
+				"  expect: %q\n  got:    %q", err, expect[i], msg)
+		}
+		if _, ok := err.(Formattable); !ok {
+			t.Errorf("%T is not Formattable", err)
+			continue
+		}
+		err.(Formattable).GetMessage()
+	}
+}
+
+// A peer may send the bad-element info after other info tags.
+func TestElementErrorsInfoOrder(t *testing.T) {
+	err := NewMissingElementProtocolError("baz")
+	err.Path = "/foo/bar"
+	err.Info = append(MgmtErrorInfo{
+		*NewMgmtErrorInfoTag(VyattaNamespace, "limit", "1024")},
+		err.Info...)
+
+	exp := "Error: /foo/bar/baz: " + err.Message
+	if msg := err.Error(); msg != exp {
+		t.Errorf("Unexpected error string\n  expect: %q\n  got:    %q",
+			exp, msg)
+	}
+}
+
 func ExampleUnknownElementApplicationError() {
 	err := NewUnknownElementApplicationError("biz")
 	err.Path = "/foo/bar"
 	fmt.Println(err.Error())
 }
 
+func ExampleUnknownElementApplicationError_SetErrorString() {
+	err := NewUnknownElementApplicationError("10.0.0.1%2F24")
+	err.Path = "/interfaces/dataplane/dp0s1/address"
+	fmt.Println(err.SetErrorString())
+
+	// Output:
+	// Configuration path: interfaces dataplane dp0s1 address [10.0.0.1/24] is not valid
+}
+
+func TestElementErrorsSetErrorString(t *testing.T) {
+	missing := NewMissingElementApplicationError("name")
+	missing.Path = "/interfaces/dataplane/"
+	noInfo := NewUnknownElementProtocolError("")
+	noInfo.Info = nil
+	noInfo.Path = "/system/foo"
+
+	tests := []struct {
+		actual, expected string
+	}{
+		{missing.SetErrorString(),
+			"Configuration path: interfaces dataplane [name] is not valid"},
+		{noInfo.SetErrorString(),
+			"Configuration path: system [foo] is not valid"},
+	}
+	for _, test := range tests {
+		if test.actual != test.expected {
+			t.Errorf("Unexpected set error\n  expect: %s\n  got:    %s",
+				test.expected, test.actual)
+		}
+	}
+}
+
 func genUnknownNamespaceXml(typ, bad_elem_value, bad_ns_value string) string {
 	return `<rpc-error xmlns="` + netconf_namespace + `">
 	<error-type>` + html.EscapeString(typ) + `</error-type>
@@ -644,6 +961,34 @@ func TestLockDeniedError(t *testing.T) {
 	verifyXmlMarshal(t, ncerr, genLockDeniedXml(sess))
 }
 
+func TestLockDeniedByProcess(t *testing.T) {
+	const holder = "configd commit (pid 1234)"
+	ncerr := NewLockDeniedByProcess(holder)
+	marshal, err := json.MarshalIndent(ncerr, "", "\t")
+	if err != nil {
+		t.Errorf("Marshal LockDeniedError error: %v\n", err)
+		return
+	}
+	unmarshal := LockDeniedError{}
+	if err := json.Unmarshal(marshal, &unmarshal); err != nil {
+		t.Errorf("Unmarshal LockDeniedError error: %v\n", err)
+		return
+	}
+	cmpMgmtError(t, ncerr.MgmtError, unmarshal.MgmtError)
+
+	if sess := unmarshal.Info.FindMgmtErrorTag("", session_id_info.String()); sess != "0" {
+		t.Errorf("Unexpected session-id: %s", sess)
+	}
+	const expMsg = "Lock is held by " + holder
+	if msg := unmarshal.GetMessage(); msg != expMsg {
+		t.Errorf("Unexpected message\n  expect: %s\n  got:    %s",
+			expMsg, msg)
+	}
+	if msg := NewLockDeniedError("1234").GetMessage(); msg != msg_nc_lock_denied {
+		t.Errorf("Unexpected message without holder: %s", msg)
+	}
+}
+
 func genResourceDeniedXml(typ string) string {
 	return `<rpc-error xmlns="` + netconf_namespace + `">
 	<error-type>` + html.EscapeString(typ) + `</error-type>
@@ -962,3 +1307,259 @@ func TestMalformedMessageError(t *testing.T) {
 
 	verifyXmlMarshal(t, ncerr, genMalformedMessageXml())
 }
+
+func TestNetconfErrorWithAppTag(t *testing.T) {
+	const appTag = "interface-in-use"
+	inUse := NewInUseApplicationError()
+	inUse.WithAppTag(appTag)
+	invalid := NewInvalidValueProtocolError()
+	invalid.WithAppTag(appTag)
+
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(inUse, invalid)
+	marshal, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatalf("Marshal error: %v\n", err)
+	}
+	var unmarshal MgmtErrorList
+	if err := json.Unmarshal(marshal, &unmarshal); err != nil {
+		t.Fatalf("Unmarshal error: %v\n", err)
+	}
+
+	if e, ok := unmarshal.Errors()[0].(*InUseApplicationError); !ok {
+		t.Errorf("Unmarshalled to %T", unmarshal.Errors()[0])
+	} else {
+		cmpMgmtError(t, inUse.MgmtError, e.MgmtError)
+	}
+	if e, ok := unmarshal.Errors()[1].(*InvalidValueProtocolError); !ok {
+		t.Errorf("Unmarshalled to %T", unmarshal.Errors()[1])
+	} else {
+		cmpMgmtError(t, invalid.MgmtError, e.MgmtError)
+	}
+}
+
+func TestCheckConsistency(t *testing.T) {
+	inUse := NewInUseApplicationError()
+	if err := inUse.CheckConsistency(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := inUse.SetType("protocol"); err != nil || inUse.Typ != "protocol" {
+		t.Errorf("Failed to set valid type: %v", err)
+	}
+	if err := inUse.SetType("transport"); err == nil || inUse.Typ != "protocol" {
+		t.Errorf("Set invalid type: %v", err)
+	}
+
+	inUse.Typ = "transport"
+	err := inUse.CheckConsistency()
+	const expErr = `invalid error type for tag: ` +
+		`error-type "transport" with error-tag "in-use"`
+	if err == nil || err.Error() != expErr {
+		t.Errorf("Unexpected consistency error\n  expect: %s\n  got:    %v",
+			expErr, err)
+	}
+	if _, err := inUse.MarshalStrict(); err == nil {
+		t.Errorf("Inconsistent error marshalled in strict mode")
+	}
+
+	if err := NewTransportBadAttrError("a", "b").CheckConsistency(); err != nil {
+		t.Errorf("Registered Vyatta error inconsistent: %v", err)
+	}
+	unknown := NewInUseApplicationError()
+	unknown.Tag = "not-a-tag"
+	if err := unknown.CheckConsistency(); err == nil {
+		t.Errorf("Unknown tag is consistent")
+	}
+}
+
+func TestFillDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      *MgmtError
+		expected *MgmtError
+	}{
+		{"netconf",
+			&MgmtError{Typ: "protocol", Tag: "in-use", Path: "/a"},
+			NewInUseProtocolError().WithPath("/a")},
+		{"yang",
+			&MgmtError{Typ: "application", Tag: "operation-failed",
+				AppTag: "must-violation"},
+			NewMustViolationError().MgmtError},
+		{"vyatta",
+			&MgmtError{Typ: "application", Tag: "invalid-value",
+				AppTag: "path-invalid", Message: "custom"},
+			newVyattaError(vyatta_invalid_value, "path-invalid", "",
+				nil).WithMessage("custom")},
+		{"unknown app-tag",
+			&MgmtError{Typ: "application", Tag: "operation-failed",
+				AppTag: "custom", Severity: "warning"},
+			NewOperationFailedApplicationError().WithAppTag("custom")},
+	}
+	tests[3].expected.Severity = "warning"
+	for _, test := range tests {
+		if err := test.err.FillDefaults(); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(test.expected, test.err) {
+			t.Errorf("%s: unexpected defaults", test.name)
+			t.Logf("Expected: %# v", pretty.Formatter(test.expected))
+			t.Logf("Result:   %# v", pretty.Formatter(test.err))
+		}
+	}
+
+	invalid := &MgmtError{Typ: "transport", Tag: "in-use"}
+	if err := invalid.FillDefaults(); err == nil {
+		t.Errorf("Defaults filled for invalid error-type")
+	}
+	if invalid.Severity != "" || invalid.Message != "" {
+		t.Errorf("Invalid error changed: %v", invalid)
+	}
+}
+
+// The error-types allowed for each error-tag by RFC6241 Appendix A,
+// listed independently of ncErrTable so that changes to it are caught.
+var rfc6241TagTypes = map[string][]string{
+	"in-use":                  {"protocol", "application"},
+	"invalid-value":           {"protocol", "application"},
+	"too-big":                 {"transport", "rpc", "protocol", "application"},
+	"missing-attribute":       {"rpc", "protocol", "application"},
+	"bad-attribute":           {"rpc", "protocol", "application"},
+	"unknown-attribute":       {"rpc", "protocol", "application"},
+	"missing-element":         {"protocol", "application"},
+	"bad-element":             {"protocol", "application"},
+	"unknown-element":         {"protocol", "application"},
+	"unknown-namespace":       {"protocol", "application"},
+	"access-denied":           {"protocol", "application"},
+	"lock-denied":             {"protocol"},
+	"resource-denied":         {"transport", "rpc", "protocol", "application"},
+	"rollback-failed":         {"protocol", "application"},
+	"data-exists":             {"application"},
+	"data-missing":            {"application"},
+	"operation-not-supported": {"protocol", "application"},
+	"operation-failed":        {"rpc", "protocol", "application"},
+	"malformed-message":       {"rpc"},
+}
+
+func TestNcErrTableTypes(t *testing.T) {
+	if len(ncerrtagmap) != len(rfc6241TagTypes) {
+		t.Errorf("Expected %d error-tags, found %d",
+			len(rfc6241TagTypes), len(ncerrtagmap))
+	}
+	for tagName, tag := range ncerrtagmap {
+		allowed, ok := rfc6241TagTypes[tagName]
+		if !ok {
+			t.Errorf("Unexpected error-tag %s", tagName)
+			continue
+		}
+		for typ := range errtypemap {
+			expErr := invalid_error_tag_type
+			for _, a := range allowed {
+				if a == typ {
+					expErr = nil
+				}
+			}
+			err := newMgmtError().setNcError(tag, typ, "", "", nil)
+			if err != expErr {
+				t.Errorf("Creating %s %s error: expected %v, got %v",
+					typ, tagName, expErr, err)
+			}
+			if err := checkTagType(tagName, typ); err != expErr {
+				t.Errorf("Checking %s %s error: expected %v, got %v",
+					typ, tagName, expErr, err)
+			}
+		}
+	}
+}
+
+func TestSetSeverity(t *testing.T) {
+	err := NewInUseProtocolError()
+	if e := err.SetSeverity(SeverityWarning); e != nil {
+		t.Errorf("Unexpected failure setting warning: %v", e)
+	}
+	if err.Severity != "warning" {
+		t.Errorf("Unexpected severity: %s", err.Severity)
+	}
+
+	const expErr = "invalid error-severity: err"
+	if e := err.SetSeverity("err"); e == nil || e.Error() != expErr {
+		t.Errorf("Unexpected result setting invalid severity: %v", e)
+	}
+	if err.Severity != "warning" {
+		t.Errorf("Severity changed by invalid setting: %s", err.Severity)
+	}
+}
+
+func TestRegisterDefaultAppTag(t *testing.T) {
+	if tag := NewOperationFailedApplicationError().AppTag; tag != "" {
+		t.Errorf("Unexpected default app-tag: %s", tag)
+	}
+
+	RegisterDefaultAppTag(operation_failed.String(), "must-violation")
+	defer delete(defaultAppTags, operation_failed.String())
+
+	if tag := NewOperationFailedApplicationError().AppTag; tag != "must-violation" {
+		t.Errorf("Default app-tag not applied: %q", tag)
+	}
+	if tag := NewOperationFailedProtocolError().AppTag; tag != "must-violation" {
+		t.Errorf("Default app-tag not applied to protocol error: %q", tag)
+	}
+	if tag := NewInUseApplicationError().AppTag; tag != "" {
+		t.Errorf("Default app-tag applied to other tag: %q", tag)
+	}
+	if tag := NewNonUniqueError([]string{"/a"}).AppTag; tag != "data-not-unique" {
+		t.Errorf("Explicit app-tag replaced: %q", tag)
+	}
+}
+
+func TestPartialOperationError(t *testing.T) {
+	const reply = `<rpc-reply message-id="101"
+  xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">
+  <rpc-error>
+    <error-type>application</error-type>
+    <error-tag>partial-operation</error-tag>
+    <error-severity>error</error-severity>
+    <error-message>` + msg_nc_partial_operation + `</error-message>
+    <error-info>
+      <ok-element>interfaces</ok-element>
+      <ok-element>system</ok-element>
+      <err-element>protocols</err-element>
+      <noop-element>service</noop-element>
+    </error-info>
+  </rpc-error>
+</rpc-reply>`
+
+	errs, err := ParseRPCReply(strings.NewReader(reply))
+	if err != nil {
+		t.Fatalf("Unexpected parse error: %s", err)
+	}
+	if _, ok := errs.Errors()[0].(*PartialOperationError); ok {
+		t.Fatalf("Obsolete error-tag parsed when not allowed")
+	}
+
+	AllowObsoleteTags = true
+	defer func() { AllowObsoleteTags = false }()
+	errs, err = ParseRPCReply(strings.NewReader(reply))
+	if err != nil {
+		t.Fatalf("Unexpected parse error: %s", err)
+	}
+	poe, ok := errs.Errors()[0].(*PartialOperationError)
+	if !ok {
+		t.Fatalf("Unexpected error type: %T", errs.Errors()[0])
+	}
+	checks := []struct {
+		name     string
+		expected []string
+		actual   []string
+	}{
+		{"ok-element", []string{"interfaces", "system"}, poe.OKElements()},
+		{"err-element", []string{"protocols"}, poe.ErrElements()},
+		{"noop-element", []string{"service"}, poe.NoopElements()},
+	}
+	for _, check := range checks {
+		if !reflect.DeepEqual(check.expected, check.actual) {
+			t.Errorf("%s: expected %v, got %v",
+				check.name, check.expected, check.actual)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Longest line accepted by DecodeNDJSON
+const maxNDJSONLine = 1024 * 1024
+
+// DecodeNDJSON reads newline delimited JSON, one error per line as
+// encoded by MgmtError.MarshalJSON, eg from a log. Each error is decoded
+// to its specific error type. Blank lines are ignored.
+//
+// On malformed input the errors decoded so far are returned, along with
+// an error identifying the line.
+func DecodeNDJSON(r io.Reader) (MgmtErrorList, error) {
+	var list MgmtErrorList
+	list.entries = []listEntry{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxNDJSONLine)
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		me := newMgmtError()
+		if err := json.Unmarshal(data, me); err != nil {
+			return list, fmt.Errorf("line %d: %v", line, err)
+		}
+		list.MgmtErrorListAppend(typedError(me))
+	}
+	if err := scanner.Err(); err != nil {
+		return list, fmt.Errorf("line %d: %v", line+1, err)
+	}
+	return list, nil
+}
+
+// WriteNDJSON writes the errors as newline delimited JSON, one compact
+// error per line in its specific encoding, as read by DecodeNDJSON.
+// Unlike MarshalJSON, there is no error-list around the errors.
+func (e MgmtErrorList) WriteNDJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, ent := range e.entries {
+		if e := enc.Encode(ent.err); e != nil {
+			return e
+		}
+	}
+	return nil
+}
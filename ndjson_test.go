@@ -0,0 +1,106 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kr/pretty"
+)
+
+func TestDecodeNDJSON(t *testing.T) {
+	errs := []error{
+		NewInUseProtocolError(),
+		NewMissingAttrApplicationError("message-id", "rpc"),
+		NewNonUniqueError([]string{"/a/b", "/a/c"}),
+		NewExecError([]string{"usr", "bin", "app"}, "failed"),
+	}
+	var lines []string
+	for i, err := range errs {
+		marshal, e := json.Marshal(err)
+		if e != nil {
+			t.Fatalf("Marshal error: %v", e)
+		}
+		lines = append(lines, string(marshal))
+		if i == 1 {
+			lines = append(lines, "", "  ")
+		}
+	}
+
+	list, err := DecodeNDJSON(strings.NewReader(strings.Join(lines, "\n")))
+	if err != nil {
+		t.Fatalf("Unexpected decode error: %v", err)
+	}
+	var exp MgmtErrorList
+	exp.MgmtErrorListAppend(errs...)
+	if !reflect.DeepEqual(exp, list) {
+		t.Errorf("Unexpected errors decoded")
+		t.Logf("Expected: %# v", pretty.Formatter(exp))
+		t.Logf("Result:   %# v", pretty.Formatter(list))
+	}
+}
+
+func TestDecodeNDJSONEmpty(t *testing.T) {
+	list, err := DecodeNDJSON(strings.NewReader("\n\n"))
+	if err != nil {
+		t.Fatalf("Unexpected decode error: %v", err)
+	}
+	if errs := list.Errors(); len(errs) != 0 {
+		t.Errorf("Unexpected errors decoded: %v", errs)
+	}
+}
+
+func TestDecodeNDJSONMalformed(t *testing.T) {
+	marshal, err := json.Marshal(NewInUseProtocolError())
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	input := string(marshal) + "\n\n{\"error-type\": \n"
+
+	list, err := DecodeNDJSON(strings.NewReader(input))
+	if err == nil {
+		t.Fatalf("Unexpected success decoding malformed input")
+	}
+	if !strings.HasPrefix(err.Error(), "line 3: ") {
+		t.Errorf("Error does not identify the line: %v", err)
+	}
+	if errs := list.Errors(); len(errs) != 1 {
+		t.Errorf("Expected the preceding error to be decoded: %v", errs)
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	var list MgmtErrorList
+	list.MgmtErrorListAppend(
+		NewInUseProtocolError(),
+		NewMissingAttrApplicationError("message-id", "rpc"),
+		NewNonUniqueError([]string{"/a/b", "/a/c"}),
+		NewExecError([]string{"usr", "bin", "app"}, "failed\nwith output"))
+
+	var out bytes.Buffer
+	if err := list.WriteNDJSON(&out); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != len(list.Errors()) {
+		t.Fatalf("Expected %d lines, got %d:\n%s",
+			len(list.Errors()), len(lines), out.String())
+	}
+
+	decoded, err := DecodeNDJSON(&out)
+	if err != nil {
+		t.Fatalf("Unexpected decode error: %v", err)
+	}
+	if !reflect.DeepEqual(list, decoded) {
+		t.Errorf("Unexpected errors decoded")
+		t.Logf("Expected: %# v", pretty.Formatter(list))
+		t.Logf("Result:   %# v", pretty.Formatter(decoded))
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"encoding/json"
+	"encoding/xml"
+)
+
+// PartialResultList reports the outcome of a non-atomic edit, in which
+// some changes take effect while others fail. It holds the paths of the
+// changes that were applied, and the errors for those that were not.
+//
+// It is encoded as a MgmtErrorList is, preceded by the applied paths,
+// so a client that only looks for the errors can decode it as a
+// MgmtErrorList.
+type PartialResultList struct {
+	applied []string
+	failed  MgmtErrorList
+}
+
+// AppendApplied records the paths of changes that took effect.
+func (r *PartialResultList) AppendApplied(paths ...string) {
+	r.applied = append(r.applied, paths...)
+}
+
+// AppendFailed records the errors for changes that did not take effect,
+// as MgmtErrorList.MgmtErrorListAppend does.
+func (r *PartialResultList) AppendFailed(errs ...error) {
+	r.failed.MgmtErrorListAppend(errs...)
+}
+
+// Applied returns the paths of the changes that took effect.
+func (r PartialResultList) Applied() []string { return r.applied }
+
+// Failed returns the errors for the changes that did not take effect.
+func (r PartialResultList) Failed() MgmtErrorList { return r.failed }
+
+// Complete reports whether every change took effect, ie none failed.
+func (r PartialResultList) Complete() bool { return r.failed.Len() == 0 }
+
+type partialResultJSON struct {
+	Applied   []string          `json:"applied"`
+	ErrorList []json.RawMessage `json:"error-list"`
+}
+
+func (r PartialResultList) MarshalJSON() ([]byte, error) {
+	out := partialResultJSON{
+		Applied:   append([]string{}, r.applied...),
+		ErrorList: []json.RawMessage{},
+	}
+	for _, ent := range r.failed.entries {
+		b, e := json.Marshal(ent.err)
+		if e != nil {
+			return nil, e
+		}
+		out.ErrorList = append(out.ErrorList, b)
+	}
+	return json.Marshal(out)
+}
+
+func (r *PartialResultList) UnmarshalJSON(value []byte) error {
+	var in struct {
+		Applied []string `json:"applied"`
+	}
+	if err := json.Unmarshal(value, &in); err != nil {
+		return err
+	}
+	if err := r.failed.UnmarshalJSON(value); err != nil {
+		return err
+	}
+	r.applied = in.Applied
+	return nil
+}
+
+// MarshalXML encodes the result as the content of an rpc-reply: an
+// applied element, in the Vyatta namespace, for each applied path,
+// followed by the rpc-error elements.
+func (r PartialResultList) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	applied := xml.StartElement{
+		Name: xml.Name{Space: VyattaNamespace, Local: "applied"},
+	}
+	for _, path := range r.applied {
+		if err := enc.EncodeElement(path, applied); err != nil {
+			return err
+		}
+	}
+	return r.failed.MarshalXML(enc, start)
+}
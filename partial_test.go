@@ -0,0 +1,89 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"testing"
+
+	"github.com/kr/pretty"
+)
+
+func genPartialResult() PartialResultList {
+	var result PartialResultList
+	result.AppendApplied("/system/host-name", "/system/ntp")
+	missing := NewDataMissingFor("delete", "/service/ssh")
+	result.AppendFailed(missing, NewMustViolationError())
+	return result
+}
+
+func TestPartialResultListJSON(t *testing.T) {
+	result := genPartialResult()
+	if result.Complete() {
+		t.Errorf("Result with failures is complete")
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+	var decoded PartialResultList
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", err)
+	}
+	if !reflect.DeepEqual(result, decoded) {
+		t.Errorf("Unexpected result decoded from %s", b)
+		t.Logf("Expected: %# v", pretty.Formatter(result))
+		t.Logf("Result:   %# v", pretty.Formatter(decoded))
+	}
+
+	var list MgmtErrorList
+	if err := json.Unmarshal(b, &list); err != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", err)
+	}
+	if !reflect.DeepEqual(result.Failed(), list) {
+		t.Errorf("Errors not decoded as a MgmtErrorList")
+	}
+}
+
+func TestPartialResultListEmptyJSON(t *testing.T) {
+	var result PartialResultList
+	if !result.Complete() {
+		t.Errorf("Empty result is not complete")
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+	const expected = `{"applied":[],"error-list":[]}`
+	if string(b) != expected {
+		t.Errorf("Unexpected JSON\n  expect: %s\n  got:    %s",
+			expected, b)
+	}
+}
+
+func TestPartialResultListXML(t *testing.T) {
+	var result PartialResultList
+	result.AppendApplied("/system/ntp")
+	result.AppendFailed(NewInUseProtocolError())
+
+	b, err := xml.Marshal(result)
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+	errXML, err := xml.Marshal(NewInUseProtocolError())
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+	expected := `<applied xmlns="` + VyattaNamespace + `">` +
+		`/system/ntp</applied>` + string(errXML)
+	if string(b) != expected {
+		t.Errorf("Unexpected XML\n  expect: %s\n  got:    %s",
+			expected, b)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ParseRPCReply extracts the errors from a NETCONF <rpc-reply>.
+//
+// Each rpc-error is decoded to its specific error type. Elements are
+// matched on their local name, so the namespace prefixes used by the
+// peer do not matter.
+func ParseRPCReply(r io.Reader) (MgmtErrorList, error) {
+	var list MgmtErrorList
+	list.entries = []listEntry{}
+
+	dec := xml.NewDecoder(r)
+	root := true
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return list, err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if root {
+			if start.Name.Local != "rpc-reply" {
+				return list, errors.New("not an rpc-reply: " +
+					start.Name.Local)
+			}
+			root = false
+			continue
+		}
+		if start.Name.Local != "rpc-error" {
+			continue
+		}
+		me := newMgmtError()
+		if err := dec.DecodeElement(me, &start); err != nil {
+			return list, err
+		}
+		list.MgmtErrorListAppend(typedError(me))
+	}
+	if root {
+		return list, errors.New("missing rpc-reply")
+	}
+	return list, nil
+}
+
+// encodeRPCReply encodes an rpc-reply, with the content written by
+// body. The message-id attribute is included if messageID is not empty.
+func encodeRPCReply(
+	messageID string,
+	body func(enc *xml.Encoder, reply xml.StartElement) error,
+) ([]byte, error) {
+	var out bytes.Buffer
+	enc := xml.NewEncoder(&out)
+
+	reply := xml.StartElement{
+		Name: xml.Name{Space: netconf_namespace, Local: "rpc-reply"},
+	}
+	if messageID != "" {
+		reply.Attr = append(reply.Attr, xml.Attr{
+			Name:  xml.Name{Local: "message-id"},
+			Value: messageID,
+		})
+	}
+	if err := enc.EncodeToken(reply); err != nil {
+		return nil, err
+	}
+	if err := body(enc, reply); err != nil {
+		return nil, err
+	}
+	if err := enc.EncodeToken(reply.End()); err != nil {
+		return nil, err
+	}
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// marshalRPCReply encodes the list as the rpc-errors of an rpc-reply.
+func (e MgmtErrorList) marshalRPCReply(messageID string) ([]byte, error) {
+	return encodeRPCReply(messageID, e.MarshalXML)
+}
+
+func encodeOk(enc *xml.Encoder, reply xml.StartElement) error {
+	ok := xml.StartElement{Name: xml.Name{Local: "ok"}}
+	if err := enc.EncodeToken(ok); err != nil {
+		return err
+	}
+	return enc.EncodeToken(ok.End())
+}
+
+// ReplyOrError encodes the rpc-reply to an RPC: <ok/> if list is empty,
+// otherwise its errors. The message-id of the RPC being replied to is
+// included if messageID is not empty.
+func ReplyOrError(list MgmtErrorList, messageID string) ([]byte, error) {
+	if list.Len() == 0 {
+		return encodeRPCReply(messageID, encodeOk)
+	}
+	return list.marshalRPCReply(messageID)
+}
+
+// Largest chunk written by WriteChunked. RFC6242 allows up to 4294967295
+// octets, but peers may limit the size they buffer.
+const maxChunkSize = 65536
+
+// WriteChunked writes the list as an rpc-reply using the chunked framing
+// of NETCONF 1.1, RFC6242 section 4.2, for sessions that have negotiated
+// the :base:1.1 capability.
+func (e MgmtErrorList) WriteChunked(w io.Writer) error {
+	data, err := e.marshalRPCReply("")
+	if err != nil {
+		return err
+	}
+	for len(data) > 0 {
+		size := len(data)
+		if size > maxChunkSize {
+			size = maxChunkSize
+		}
+		if _, err := fmt.Fprintf(w, "\n#%d\n", size); err != nil {
+			return err
+		}
+		if _, err := w.Write(data[:size]); err != nil {
+			return err
+		}
+		data = data[size:]
+	}
+	_, err = io.WriteString(w, "\n##\n")
+	return err
+}
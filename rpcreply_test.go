@@ -0,0 +1,236 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kr/pretty"
+)
+
+func checkParsedReply(t *testing.T, reply string, exp MgmtErrorList) {
+	errs, err := ParseRPCReply(strings.NewReader(reply))
+	if err != nil {
+		t.Fatalf("Unexpected parse error: %s", err)
+	}
+	if !reflect.DeepEqual(exp, errs) {
+		t.Errorf("Unexpected errors parsed from rpc-reply")
+		t.Logf("Expected: %# v", pretty.Formatter(exp))
+		t.Logf("Result:   %# v", pretty.Formatter(errs))
+	}
+}
+
+func TestParseRPCReply(t *testing.T) {
+	const reply = `<rpc-reply message-id="101"
+  xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">
+  <rpc-error>
+    <error-type>protocol</error-type>
+    <error-tag>in-use</error-tag>
+    <error-severity>error</error-severity>
+    <error-message>` + msg_nc_in_use + `</error-message>
+  </rpc-error>
+  <rpc-error>
+    <error-type>application</error-type>
+    <error-tag>missing-attribute</error-tag>
+    <error-severity>error</error-severity>
+    <error-message>` + msg_nc_missing_attribute + `</error-message>
+    <error-info>
+      <bad-attribute>message-id</bad-attribute>
+      <bad-element>rpc</bad-element>
+    </error-info>
+  </rpc-error>
+  <rpc-error>
+    <error-type>application</error-type>
+    <error-tag>operation-failed</error-tag>
+    <error-severity>error</error-severity>
+    <error-app-tag>data-not-unique</error-app-tag>
+    <error-message>` + msg_yang_operation_failed + `</error-message>
+    <error-info>
+      <non-unique xmlns="urn:ietf:params:xml:ns:yang:1">/a/b</non-unique>
+      <non-unique xmlns="urn:ietf:params:xml:ns:yang:1">/a/c</non-unique>
+    </error-info>
+  </rpc-error>
+</rpc-reply>`
+
+	var exp MgmtErrorList
+	exp.MgmtErrorListAppend(NewInUseProtocolError(),
+		NewMissingAttrApplicationError("message-id", "rpc"),
+		NewNonUniqueError([]string{"/a/b", "/a/c"}))
+	checkParsedReply(t, reply, exp)
+}
+
+func TestParseRPCReplyNoErrors(t *testing.T) {
+	const reply = `<rpc-reply message-id="101"
+  xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"><ok/></rpc-reply>`
+
+	exp := MgmtErrorList{entries: []listEntry{}}
+	checkParsedReply(t, reply, exp)
+}
+
+func TestReplyOrError(t *testing.T) {
+	var list MgmtErrorList
+	reply, err := ReplyOrError(list, "101")
+	if err != nil {
+		t.Fatalf("Unexpected ok reply error: %v", err)
+	}
+	const expOk = `<rpc-reply xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"` +
+		` message-id="101"><ok></ok></rpc-reply>`
+	if string(reply) != expOk {
+		t.Errorf("Unexpected ok reply\n  expect: %s\n  got:    %s",
+			expOk, reply)
+	}
+	checkParsedReply(t, string(reply), MgmtErrorList{entries: []listEntry{}})
+
+	list.MgmtErrorListAppend(NewInUseProtocolError())
+	reply, err = ReplyOrError(list, "")
+	if err != nil {
+		t.Fatalf("Unexpected error reply error: %v", err)
+	}
+	if bytes.Contains(reply, []byte("message-id")) ||
+		bytes.Contains(reply, []byte("<ok>")) {
+		t.Errorf("Unexpected content in error reply: %s", reply)
+	}
+	checkParsedReply(t, string(reply), list)
+}
+
+func TestParseRPCReplyInvalid(t *testing.T) {
+	for _, reply := range []string{
+		``,
+		`<rpc message-id="101"/>`,
+		`<rpc-reply><rpc-error><error-type>rpc</rpc-error></rpc-reply>`,
+	} {
+		if _, err := ParseRPCReply(strings.NewReader(reply)); err == nil {
+			t.Errorf("Unexpected success parsing: %s", reply)
+		}
+	}
+}
+
+func TestParseRPCReplyPrefixed(t *testing.T) {
+	// As sent by a Junos device, with the NETCONF namespace prefixed
+	const junosReply = `<nc:rpc-reply
+  xmlns:nc="urn:ietf:params:xml:ns:netconf:base:1.0"
+  xmlns:junos="http://xml.juniper.net/junos/17.3R1/junos"
+  message-id="urn:uuid:2d2f6dc8-2b9e-4d4a-8d2e-5b1a0e8c6f11">
+<nc:rpc-error>
+<nc:error-type>protocol</nc:error-type>
+<nc:error-tag>operation-failed</nc:error-tag>
+<nc:error-severity>error</nc:error-severity>
+<nc:error-message>syntax error, expecting &lt;candidate/&gt; or &lt;running/&gt;</nc:error-message>
+<nc:error-info>
+<nc:bad-element>non-exist</nc:bad-element>
+</nc:error-info>
+</nc:rpc-error>
+</nc:rpc-reply>`
+
+	opFailed := NewOperationFailedProtocolError()
+	opFailed.Message = "syntax error, expecting <candidate/> or <running/>"
+	opFailed.Info = append(opFailed.Info,
+		*NewMgmtErrorInfoTag("", "bad-element", "non-exist"))
+	var exp MgmtErrorList
+	exp.MgmtErrorListAppend(opFailed)
+	checkParsedReply(t, junosReply, exp)
+
+	// Prefixes differing between the reply and its errors, with the
+	// YANG error-info also prefixed
+	const mixedReply = `<rpc-reply message-id="101"
+  xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">
+  <ncbase:rpc-error xmlns:ncbase="urn:ietf:params:xml:ns:netconf:base:1.0">
+    <ncbase:error-type>application</ncbase:error-type>
+    <ncbase:error-tag>missing-attribute</ncbase:error-tag>
+    <ncbase:error-severity>error</ncbase:error-severity>
+    <ncbase:error-message>` + msg_nc_missing_attribute + `</ncbase:error-message>
+    <ncbase:error-info>
+      <ncbase:bad-attribute>message-id</ncbase:bad-attribute>
+      <ncbase:bad-element>rpc</ncbase:bad-element>
+    </ncbase:error-info>
+  </ncbase:rpc-error>
+  <nc:rpc-error xmlns:nc="urn:ietf:params:xml:ns:netconf:base:1.0"
+    xmlns:yang="urn:ietf:params:xml:ns:yang:1">
+    <nc:error-type>application</nc:error-type>
+    <nc:error-tag>operation-failed</nc:error-tag>
+    <nc:error-severity>error</nc:error-severity>
+    <nc:error-app-tag>data-not-unique</nc:error-app-tag>
+    <nc:error-message>` + msg_yang_operation_failed + `</nc:error-message>
+    <nc:error-info>
+      <yang:non-unique>/a/b</yang:non-unique>
+      <yang:non-unique>/a/c</yang:non-unique>
+    </nc:error-info>
+  </nc:rpc-error>
+</rpc-reply>`
+
+	exp = MgmtErrorList{}
+	exp.MgmtErrorListAppend(
+		NewMissingAttrApplicationError("message-id", "rpc"),
+		NewNonUniqueError([]string{"/a/b", "/a/c"}))
+	checkParsedReply(t, mixedReply, exp)
+}
+
+// deframeChunks decodes a message in RFC6242 chunked framing.
+func deframeChunks(t *testing.T, framed []byte) []byte {
+	var msg bytes.Buffer
+	r := bufio.NewReader(bytes.NewReader(framed))
+	for {
+		var size int
+		if _, err := fmt.Fscanf(r, "\n#"); err != nil {
+			t.Fatalf("Missing chunk header: %v", err)
+		}
+		if b, _ := r.Peek(2); string(b) == "#\n" {
+			r.Discard(2)
+			break
+		}
+		if _, err := fmt.Fscanf(r, "%d\n", &size); err != nil {
+			t.Fatalf("Invalid chunk size: %v", err)
+		}
+		if size < 1 || size > 4294967295 {
+			t.Fatalf("Chunk size %d out of range", size)
+		}
+		if _, err := io.CopyN(&msg, r, int64(size)); err != nil {
+			t.Fatalf("Short chunk: %v", err)
+		}
+	}
+	if rest, _ := ioutil.ReadAll(r); len(rest) != 0 {
+		t.Fatalf("Data after end of chunks: %q", rest)
+	}
+	return msg.Bytes()
+}
+
+func TestWriteChunked(t *testing.T) {
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(NewInUseProtocolError(),
+		NewMissingAttrApplicationError("message-id", "rpc"))
+
+	var framed bytes.Buffer
+	if err := errs.WriteChunked(&framed); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	if !bytes.HasPrefix(framed.Bytes(), []byte("\n#")) ||
+		!bytes.HasSuffix(framed.Bytes(), []byte("\n##\n")) {
+		t.Errorf("Not chunk framed: %q", framed.Bytes())
+	}
+	checkParsedReply(t, string(deframeChunks(t, framed.Bytes())), errs)
+}
+
+func TestWriteChunkedLarge(t *testing.T) {
+	var errs MgmtErrorList
+	for i := 0; i < 500; i++ {
+		errs.MgmtErrorListAppend(NewInUseProtocolError())
+	}
+	var framed bytes.Buffer
+	if err := errs.WriteChunked(&framed); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	if n := bytes.Count(framed.Bytes(), []byte("\n#")); n < 3 {
+		t.Errorf("Expected multiple chunks, got %d headers", n)
+	}
+	checkParsedReply(t, string(deframeChunks(t, framed.Bytes())), errs)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror_test
+
+import (
+	"encoding/xml"
+	"errors"
+	"testing"
+
+	"github.com/danos/mgmterror"
+	"github.com/danos/mgmterror/errtest"
+)
+
+func TestMarshalledErrorsMatchNetconfSchema(t *testing.T) {
+	withPath := func(err *mgmterror.MgmtError) error {
+		err.Path = "/foo/bar"
+		return err
+	}
+	errs := []error{
+		mgmterror.NewAccessDeniedApplicationError(),
+		mgmterror.NewBadAttrProtocolError("attr", "elem"),
+		mgmterror.NewUnknownElementApplicationError("elem"),
+		mgmterror.NewLockDeniedError("42"),
+		mgmterror.NewNonUniqueError([]string{"/foo/a", "/foo/b"}),
+		mgmterror.NewTooManyElementsErrorWithCount("/foo", 5, 4),
+		withPath(mgmterror.NewMustViolationError().MgmtError),
+		mgmterror.NewInstanceRequiredErrorFor("/foo", "/bar"),
+		mgmterror.NewMissingChoiceError("/foo", "choice"),
+		mgmterror.NewInsertFailedErrorFor("/foo", "key", "[k='v']"),
+		mgmterror.NewExecError([]string{"usr", "bin", "app"}, "failed"),
+		mgmterror.NewPathAmbiguousError([]string{"s"},
+			map[string]string{"system": "System parameters"}),
+		mgmterror.NewInvalidPathError("/foo/bar"),
+		mgmterror.NewTransportBadAttrError("chunk-size", "frame"),
+		withPath(mgmterror.NewWhenViolationError().MgmtError),
+	}
+	for _, err := range errs {
+		marshal, e := xml.Marshal(err)
+		if e != nil {
+			t.Errorf("%T: marshal error: %v", err, e)
+			continue
+		}
+		errtest.ValidateAgainstNetconfSchema(t, marshal)
+	}
+
+	var list mgmterror.MgmtErrorList
+	list.MgmtErrorListAppend(errs...)
+	list.MgmtErrorListAppend(errors.New("plain error"))
+	marshal, err := xml.Marshal(list)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	errtest.ValidateAgainstNetconfSchema(t, marshal)
+
+	strict, err := list.MarshalStrict()
+	if err != nil {
+		t.Fatalf("Strict marshal error: %v", err)
+	}
+	errtest.ValidateAgainstNetconfSchema(t, strict)
+}
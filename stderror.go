@@ -0,0 +1,54 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+)
+
+// FromStdError converts an error returned by the standard library, eg
+// from a filesystem or network operation, to the matching error type:
+//
+//   - not exist: data-missing
+//   - exist: data-exists
+//   - permission: access-denied
+//   - context deadline exceeded or a network timeout: resource-denied
+//
+// Any other error is an operation-failed error. The error-message is
+// that of err, which is recorded as the cause. An err which is already
+// one of the errors of this package is returned unchanged, and a nil err
+// gives nil.
+func FromStdError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(Formattable); ok {
+		return err
+	}
+	me := newStdError(err)
+	me.getMgmtError().WithMessage(err.Error()).WithCause(err)
+	return me.(error)
+}
+
+// newStdError returns a new error of the type matching err.
+func newStdError(err error) MgmtErrorRef {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return NewDataMissingError()
+	case errors.Is(err, os.ErrExist):
+		return NewDataExistsError()
+	case errors.Is(err, os.ErrPermission):
+		return NewAccessDeniedApplicationError()
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return NewResourceDeniedApplicationError()
+	}
+	return NewOperationFailedApplicationError()
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestFromStdError(t *testing.T) {
+	_, notExist := os.Open("/nonexistent/mgmterror-test")
+	wrapped := fmt.Errorf("saving config: %w", os.ErrPermission)
+
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{"not exist", notExist, &DataMissingError{}},
+		{"exist", os.ErrExist, &DataExistsError{}},
+		{"permission", wrapped, &AccessDeniedApplicationError{}},
+		{"deadline", context.DeadlineExceeded,
+			&ResourceDeniedApplicationError{}},
+		{"net timeout", timeoutError{}, &ResourceDeniedApplicationError{}},
+		{"other", errors.New("boom"), &OperationFailedApplicationError{}},
+	}
+	for _, test := range tests {
+		err := FromStdError(test.err)
+		if reflect.TypeOf(err) != reflect.TypeOf(test.expected) {
+			t.Errorf("%s: expected %T, got %T", test.name, test.expected,
+				err)
+			continue
+		}
+		if msg := err.(Formattable).GetMessage(); msg != test.err.Error() {
+			t.Errorf("%s: unexpected message: %s", test.name, msg)
+		}
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: cause not preserved", test.name)
+		}
+	}
+
+	if !errors.Is(FromStdError(notExist), os.ErrNotExist) {
+		t.Errorf("Cause not found through the typed error")
+	}
+	inUse := NewInUseProtocolError()
+	if err := FromStdError(inUse); err != inUse {
+		t.Errorf("Error of this package converted: %v", err)
+	}
+	if err := FromStdError(nil); err != nil {
+		t.Errorf("Unexpected error from nil: %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"bytes"
+	"encoding/xml"
+)
+
+// isStrictInfoTag reports whether an error-info tag is defined by
+// RFC6241 or RFC6020, ie is in the NETCONF or YANG namespace.
+func isStrictInfoTag(t MgmtErrorInfoTag) bool {
+	switch t.XMLName.Space {
+	case "", netconf_namespace, yang_namespace:
+		return true
+	}
+	return false
+}
+
+// strictCopy returns a copy of the error without any error-info tags
+// that are not defined by the RFCs, nor attributes on the remaining
+// ones.
+func (e *MgmtError) strictCopy() *MgmtError {
+	c := *e
+	c.setXMLName()
+	c.Info = nil
+	for _, t := range e.Info {
+		if !isStrictInfoTag(t) {
+			continue
+		}
+		t.Attrs = nil
+		c.Info = append(c.Info, t)
+	}
+	return &c
+}
+
+// MarshalStrict encodes the error as XML containing only the elements
+// and namespaces defined by RFC6241 and RFC6020, for peers that reject
+// anything else. Vendor specific error-info, such as that in the
+// Vyatta namespace, is dropped.
+//
+// An error is returned if the error-type and error-tag are not a
+// consistent combination, see CheckConsistency.
+//
+// As this is lossy, it should only be used when the peer is known to
+// require it; the default encoding round-trips through this package.
+func (e *MgmtError) MarshalStrict() ([]byte, error) {
+	if err := e.CheckConsistency(); err != nil {
+		return nil, err
+	}
+	return xml.Marshal(e.strictCopy())
+}
+
+// MarshalStrict encodes each error in the list as MgmtError.MarshalStrict
+// does. Errors not based on a MgmtError are reported as a generic
+// operation-failed error.
+func (e MgmtErrorList) MarshalStrict() ([]byte, error) {
+	var out bytes.Buffer
+	for _, ent := range e.entries {
+		b, err := asMgmtError(ent.err).MarshalStrict()
+		if err != nil {
+			return out.Bytes(), err
+		}
+		out.Write(b)
+	}
+	return out.Bytes(), nil
+}
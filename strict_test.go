@@ -0,0 +1,91 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func genStrictTestError() *MgmtError {
+	err := NewMustViolationError().MgmtError
+	err.Path = "/foo/bar"
+	err.Info = MgmtErrorInfo{
+		*NewMgmtErrorInfoTag("", bad_element_info.String(), "bar"),
+		*NewMgmtErrorInfoTag(yang_namespace, non_unique_info.String(),
+			"/foo/bar/baz"),
+		{
+			XMLName: xml.Name{Space: VyattaNamespace, Local: "limit"},
+			Value:   "1024",
+			Attrs: []xml.Attr{
+				{Name: xml.Name{Local: "unit"}, Value: "bytes"}},
+		},
+	}
+	return err
+}
+
+const strictTestErrorXml = `<rpc-error xmlns="` + netconf_namespace + `">` +
+	`<error-type>application</error-type>` +
+	`<error-tag>operation-failed</error-tag>` +
+	`<error-severity>error</error-severity>` +
+	`<error-app-tag>must-violation</error-app-tag>` +
+	`<error-path>/foo/bar</error-path>` +
+	`<error-message>` + msg_yang_operation_failed + `</error-message>` +
+	`<error-info>` +
+	`<bad-element>bar</bad-element>` +
+	`<non-unique xmlns="` + yang_namespace + `">/foo/bar/baz</non-unique>` +
+	`</error-info>` +
+	`</rpc-error>`
+
+func TestMarshalStrict(t *testing.T) {
+	err := genStrictTestError()
+	marshal, e := err.MarshalStrict()
+	if e != nil {
+		t.Fatalf("Unexpected strict marshal error: %v", e)
+	}
+	if string(marshal) != strictTestErrorXml {
+		t.Errorf("Unexpected strict XML")
+		t.Logf("Expected: %s", strictTestErrorXml)
+		t.Logf("Marshal:  %s", marshal)
+	}
+
+	// The default encoding is unchanged
+	if len(err.Info) != 3 {
+		t.Errorf("Error modified by strict marshal: %v", err.Info)
+	}
+	def, e := xml.Marshal(err)
+	if e != nil {
+		t.Fatalf("Unexpected marshal error: %v", e)
+	}
+	if !strings.Contains(string(def), VyattaNamespace) {
+		t.Errorf("Vyatta info missing from default encoding: %s", def)
+	}
+}
+
+func TestMgmtErrorListMarshalStrict(t *testing.T) {
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(createMustViolationError(genStrictTestError()))
+	errs.MgmtErrorListAppend(errors.New("plain error"))
+
+	marshal, err := errs.MarshalStrict()
+	if err != nil {
+		t.Fatalf("Unexpected strict marshal error: %v", err)
+	}
+	expected := strictTestErrorXml +
+		`<rpc-error xmlns="` + netconf_namespace + `">` +
+		`<error-type>application</error-type>` +
+		`<error-tag>operation-failed</error-tag>` +
+		`<error-severity>error</error-severity>` +
+		`<error-message>plain error</error-message>` +
+		`</rpc-error>`
+	if string(marshal) != expected {
+		t.Errorf("Unexpected strict XML")
+		t.Logf("Expected: %s", expected)
+		t.Logf("Marshal:  %s", marshal)
+	}
+}
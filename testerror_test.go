@@ -0,0 +1,121 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/danos/mgmterror"
+	"github.com/danos/mgmterror/errtest"
+)
+
+// Check the raw strings expected by errtest are those of the equivalent
+// real errors.
+func TestTestErrorToMgmtError(t *testing.T) {
+	tests := []struct {
+		name string
+		te   *errtest.TestError
+	}{
+		{"access denied", errtest.NewAccessDeniedError(t, "/foo/bar")},
+		{"invalid node", errtest.NewInvalidNodeError(t, "/foo/bar")},
+		{"invalid path", errtest.NewInvalidPathError(t, "/foo/bar")},
+		{"schema mismatch", errtest.NewSchemaMismatchError(t, "/foo/bar")},
+	}
+	for _, test := range tests {
+		err := test.te.ToMgmtError()
+		if err == nil {
+			t.Errorf("%s: no MgmtError", test.name)
+			continue
+		}
+		actual := err.(error).Error()
+		for _, exp := range test.te.RawErrorStrings() {
+			if !strings.Contains(actual, exp) {
+				t.Errorf("%s: %q not found in %q", test.name, exp,
+					actual)
+			}
+		}
+	}
+}
+
+// The leafref expectation is the message composed by the configuration
+// system, so only the content of the real error can be checked.
+func TestLeafrefTestErrorToMgmtError(t *testing.T) {
+	err := errtest.NewLeafrefError(t, "/foo/bar", "/baz/qux").ToMgmtError()
+	if path := err.GetPath(); path != "/foo/bar" {
+		t.Errorf("Unexpected path: %s", path)
+	}
+	if appTag := err.GetAppTag(); appTag != "instance-required" {
+		t.Errorf("Unexpected app-tag: %s", appTag)
+	}
+	const expMsg = "require-instance: /baz/qux does not exist"
+	if msg := err.GetMessage(); msg != expMsg {
+		t.Errorf("Unexpected message\n  expect: %s\n  got:    %s",
+			expMsg, msg)
+	}
+}
+
+// The node exists and doesn't exist expectations are the messages of
+// the configuration system, so only the content of the real errors can
+// be checked.
+func TestDataTestErrorToMgmtError(t *testing.T) {
+	tests := []struct {
+		name   string
+		te     *errtest.TestError
+		tag    string
+		expMsg string
+	}{
+		{"node exists", errtest.NewNodeExistsError(t, "/foo/bar"),
+			"data-exists", "cannot create /foo/bar: data already exists"},
+		{"node doesn't exist", errtest.NewNodeDoesntExistError(t, "/foo/bar"),
+			"data-missing", "cannot delete /foo/bar: data does not exist"},
+	}
+	for _, test := range tests {
+		err := test.te.ToMgmtError()
+		if path := err.GetPath(); path != "/foo/bar" {
+			t.Errorf("%s: unexpected path: %s", test.name, path)
+		}
+		if tag := err.GetTag(); tag != test.tag {
+			t.Errorf("%s: unexpected tag: %s", test.name, tag)
+		}
+		if msg := err.GetMessage(); msg != test.expMsg {
+			t.Errorf("%s: unexpected message\n  expect: %s\n  got:    %s",
+				test.name, test.expMsg, msg)
+		}
+	}
+}
+
+// checkFails reports whether check fails the test it is given.
+func checkFails(check func(t *testing.T)) bool {
+	var t testing.T
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		check(&t)
+	}()
+	<-done
+	return t.Failed()
+}
+
+func TestCheckMgmtErrorExact(t *testing.T) {
+	tooMany := mgmterror.NewTooManyElementsErrorWithCount("/foo", 3, 2)
+	same, _ := mgmterror.AsMgmtError(tooMany)
+	expected := *same
+	expected.Info = append(mgmterror.MgmtErrorInfo(nil), same.Info...)
+
+	if checkFails(func(t *testing.T) {
+		errtest.CheckMgmtErrorExact(t, tooMany, &expected)
+	}) {
+		t.Errorf("Error with composed message does not match itself")
+	}
+
+	expected.Path = "/bar"
+	if !checkFails(func(t *testing.T) {
+		errtest.CheckMgmtErrorExact(t, tooMany, &expected)
+	}) {
+		t.Errorf("Error with a different path matches")
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Optional validation modes used by Validate. These are off by default
+// so that existing lenient callers are not affected.
+var (
+	// RequirePath makes Validate reject an error without a Path,
+	// unless it has been explicitly marked with WithNoPath.
+	RequirePath bool
+
+	// PanicOnInvalidInfoTag makes NewMgmtErrorInfoTag panic if it is
+	// given an empty name, to catch the construction bug in tests.
+	PanicOnInvalidInfoTag bool
+
+	// RequireAbsolutePath makes Validate reject a NETCONF or YANG
+	// error whose error-path, or a path in its YANG error-info, is not
+	// absolute, as RFC6241 and RFC6020 require. Vyatta errors are not
+	// checked, as eg the path of an InvalidPathError is the path that
+	// could not be parsed.
+	RequireAbsolutePath bool
+
+	// PanicOnRelativePath makes the YANG error constructors panic if
+	// they are given a path that is not absolute, eg a bare node name,
+	// to catch the construction bug in tests.
+	PanicOnRelativePath bool
+)
+
+// WithNoPath marks the error as intentionally having no error-path, ie
+// no appropriate payload element or datastore node can be associated
+// with it. Any Path already set is cleared.
+//
+// This distinguishes an absent path from a forgotten one when
+// validating with RequirePath.
+func (e *MgmtError) WithNoPath() *MgmtError {
+	e.Path = ""
+	e.noPath = true
+	return e
+}
+
+// Validate checks that the error is well formed, returning a
+// description of the first problem found.
+func (e *MgmtError) Validate() error {
+	if e.Typ == "" {
+		return errors.New("missing error-type")
+	}
+	if _, ok := errtypemap[e.Typ]; !ok {
+		return fmt.Errorf("invalid error-type: %s", e.Typ)
+	}
+	if e.Tag == "" {
+		return errors.New("missing error-tag")
+	}
+	if e.Severity == "" {
+		return errors.New("missing error-severity")
+	}
+	if !Severity(e.Severity).valid() {
+		return fmt.Errorf("invalid error-severity: %s", e.Severity)
+	}
+	for i, t := range e.Info {
+		if t.XMLName.Local == "" {
+			return fmt.Errorf("error-info tag %d has no name", i)
+		}
+	}
+	if e.noPath && e.Path != "" {
+		return fmt.Errorf("error-path %s set on error marked as having "+
+			"no path", e.Path)
+	}
+	if RequirePath && !e.noPath && e.Path == "" {
+		return errors.New("missing error-path")
+	}
+	if RequireAbsolutePath {
+		return e.checkAbsolutePaths()
+	}
+	return nil
+}
+
+// checkAbsolutePaths returns an error if the error-path, or a path in
+// the YANG error-info, of a NETCONF or YANG error is not absolute.
+func (e *MgmtError) checkAbsolutePaths() error {
+	if _, ok := vyattaErrorCreator(e); ok {
+		return nil
+	}
+	if !isAbsolutePath(e.Path) {
+		return fmt.Errorf("error-path %s is not absolute", e.Path)
+	}
+	for _, t := range e.Info {
+		if t.XMLName.Space == yang_namespace && isPathInfoTag(t) &&
+			!isAbsolutePath(t.Value) {
+			return fmt.Errorf("error-info %s path %s is not absolute",
+				t.XMLName.Local, t.Value)
+		}
+	}
+	return nil
+}
+
+// isAbsolutePath reports whether path is absolute, or is empty.
+func isAbsolutePath(path string) bool {
+	return path == "" || strings.HasPrefix(path, "/")
+}
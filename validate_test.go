@@ -0,0 +1,116 @@
+// Copyright (c) 2026, elster-drones/mgmterror contributors.
+// All rights reserved.
+//
+// SPDX-License-Identifier: MPL-2.0
+
+package mgmterror
+
+import (
+	"testing"
+)
+
+func checkValid(t *testing.T, name string, e *MgmtError, expValid bool) {
+	err := e.Validate()
+	if expValid && err != nil {
+		t.Errorf("%s: unexpected validation failure: %s", name, err)
+	}
+	if !expValid && err == nil {
+		t.Errorf("%s: unexpected validation success", name)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	checkValid(t, "constructed", NewInUseProtocolError().MgmtError, true)
+
+	e := NewInUseProtocolError().MgmtError
+	e.Typ = ""
+	checkValid(t, "no type", e, false)
+
+	e = NewInUseProtocolError().MgmtError
+	e.Typ = "session"
+	checkValid(t, "bad type", e, false)
+
+	e = NewInUseProtocolError().MgmtError
+	e.Tag = ""
+	checkValid(t, "no tag", e, false)
+
+	e = NewInUseProtocolError().MgmtError
+	e.Severity = ""
+	checkValid(t, "no severity", e, false)
+
+	e = NewInUseProtocolError().MgmtError
+	e.Severity = "err"
+	checkValid(t, "bad severity", e, false)
+
+	e = NewInUseProtocolError().MgmtError
+	e.Severity = "warning"
+	checkValid(t, "warning", e, true)
+
+	e = NewInUseProtocolError().MgmtError
+	e.Info = append(e.Info, *NewMgmtErrorInfoTag(VyattaNamespace, "", "x"))
+	checkValid(t, "info tag without name", e, false)
+
+	e = NewInUseProtocolError().WithNoPath()
+	e.Path = "/foo"
+	checkValid(t, "path with no path marker", e, false)
+}
+
+func TestValidateRequirePath(t *testing.T) {
+	RequirePath = true
+	defer func() { RequirePath = false }()
+
+	checkValid(t, "forgotten path", NewMustViolationError().MgmtError, false)
+	checkValid(t, "no path", NewMustViolationError().WithNoPath(), true)
+
+	e := NewMustViolationError()
+	e.Path = "/foo/bar"
+	checkValid(t, "path", e.MgmtError, true)
+}
+
+func TestValidateRequireAbsolutePath(t *testing.T) {
+	relative := NewMustViolationError()
+	relative.Path = "foo/bar"
+	checkValid(t, "relative path unchecked", relative.MgmtError, true)
+
+	RequireAbsolutePath = true
+	defer func() { RequireAbsolutePath = false }()
+
+	checkValid(t, "relative path", relative.MgmtError, false)
+	checkValid(t, "relative info path",
+		NewNonUniqueError([]string{"/foo/bar", "baz"}).MgmtError, false)
+	checkValid(t, "no path", NewMustViolationError().MgmtError, true)
+	checkValid(t, "absolute path",
+		NewNonUniqueError([]string{"/foo/bar", "/baz"}).MgmtError, true)
+	checkValid(t, "vyatta path",
+		NewInvalidPathError("foo bar").MgmtError, true)
+}
+
+func TestRelativePathPanics(t *testing.T) {
+	NewTooManyElementsError("foo")
+
+	PanicOnRelativePath = true
+	defer func() { PanicOnRelativePath = false }()
+
+	NewTooManyElementsError("/foo")
+	defer func() {
+		if recover() == nil {
+			t.Errorf("No panic creating error with relative path")
+		}
+	}()
+	NewTooManyElementsError("foo")
+}
+
+func TestInfoTagWithoutName(t *testing.T) {
+	if _, err := NewMgmtErrorInfoTagByModule(vyattaModule, "", "x"); err == nil {
+		t.Errorf("Unexpected success creating info tag without name")
+	}
+
+	PanicOnInvalidInfoTag = true
+	defer func() { PanicOnInvalidInfoTag = false }()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("No panic creating info tag without name")
+		}
+	}()
+	NewMgmtErrorInfoTag(VyattaNamespace, "", "x")
+}
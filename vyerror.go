@@ -11,8 +11,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
-	"strings"
+	"reflect"
+	"strconv"
 
 	"github.com/danos/utils/natsort"
 	"github.com/danos/utils/pathutil"
@@ -22,10 +24,12 @@ type vyErrTag uint
 
 const (
 	vyatta_operation_failed vyErrTag = iota
+	vyatta_invalid_value
 )
 
 var vyErrTagMap = map[string]vyErrTag{
 	"operation-failed": vyatta_operation_failed,
+	"invalid-value":    vyatta_invalid_value,
 }
 
 func (t vyErrTag) String() string {
@@ -43,11 +47,19 @@ type vyErrAppTagId uint
 const (
 	exec_failed vyErrAppTagId = iota
 	path_ambig
+	path_invalid
+	schema_mismatch
+	when_violation
+	config_path_invalid
 )
 
 var vyErrAppTagMap = map[string]vyErrAppTagId{
-	"exec-failed":    exec_failed,
-	"path-ambiguous": path_ambig,
+	"exec-failed":         exec_failed,
+	"path-ambiguous":      path_ambig,
+	"path-invalid":        path_invalid,
+	"schema-mismatch":     schema_mismatch,
+	"when-violation":      when_violation,
+	"config-path-invalid": config_path_invalid,
 }
 
 func (t vyErrAppTagId) String() string {
@@ -76,33 +88,249 @@ func init() {
 			severity: yang_severity_error,
 			msg:      msg_yang_operation_failed,
 			apptag: vyAppTagMap{
-				exec_failed: createExecError,
-				path_ambig:  createPathAmbigError,
+				exec_failed:    createExecError,
+				path_ambig:     createPathAmbigError,
+				when_violation: createWhenViolationError,
+			},
+		},
+		vyatta_invalid_value: {
+			severity: yang_severity_error,
+			msg:      msg_nc_invalid_value,
+			apptag: vyAppTagMap{
+				path_invalid:        createInvalidPathError,
+				schema_mismatch:     createSchemaMismatchError,
+				config_path_invalid: createConfigPathInvalidError,
 			},
 		},
 	}
 }
 
-func getVyattaError(err *MgmtError) error {
+// Vyatta errors added with RegisterVyattaError. Unlike the errors in
+// vyErrTable, these need not be application errors so are also keyed by
+// type.
+type vyRegisteredKey struct {
+	typ, tag, apptag string
+}
+
+var vyRegisteredErrors = map[vyRegisteredKey]interface{}{}
+
+// RegisterVyattaError adds a Vyatta error identified by its type, tag
+// and app-tag, so that it is decoded to its own type when unmarshalling
+// a MgmtErrorList.
+//
+// create must be a function taking a *MgmtError and returning the
+// type wrapping it, eg createExecError.
+func RegisterVyattaError(typ, tag, apptag string, create interface{}) error {
+	if _, ok := errtypemap[typ]; !ok {
+		return invalid_error_type
+	}
+	if tag == "" {
+		return invalid_error_tag
+	}
+	if apptag == "" {
+		return invalid_error_app_tag
+	}
+	ty := reflect.TypeOf(create)
+	if ty == nil || ty.Kind() != reflect.Func ||
+		ty.NumIn() != 1 || ty.In(0) != reflect.TypeOf((*MgmtError)(nil)) ||
+		ty.NumOut() != 1 ||
+		!ty.Out(0).Implements(reflect.TypeOf((*error)(nil)).Elem()) {
+		return errors.New("invalid error create function")
+	}
+	key := vyRegisteredKey{typ: typ, tag: tag, apptag: apptag}
+	if _, ok := vyRegisteredErrors[key]; ok {
+		return fmt.Errorf("error %s/%s/%s already registered",
+			typ, tag, apptag)
+	}
+	vyRegisteredErrors[key] = create
+	return nil
+}
+
+// vyattaErrorCreator returns the function creating the Vyatta error
+// type matching err, if there is one.
+func vyattaErrorCreator(err *MgmtError) (interface{}, bool) {
+	key := vyRegisteredKey{typ: err.Typ, tag: err.Tag, apptag: err.AppTag}
+	if fn, ok := vyRegisteredErrors[key]; ok {
+		return fn, true
+	}
 	tag, ok := vyErrTagMap[err.Tag]
 	if !ok {
-		return nil
+		return nil, false
 	}
 	errtag, ok := vyErrTable[tag]
 	if !ok {
-		return nil
+		return nil, false
 	}
 	apptag, ok := vyErrAppTagMap[err.AppTag]
 	if !ok {
-		return nil
+		return nil, false
 	}
 	fn, ok := errtag.apptag[apptag]
+	return fn, ok
+}
+
+func getVyattaError(err *MgmtError) error {
+	fn, ok := vyattaErrorCreator(err)
 	if !ok {
 		return nil
 	}
 	return callCreate(fn, err)
 }
 
+type vyErrInfoId uint
+
+const (
+	related_path_info vyErrInfoId = iota
+	exit_status_info
+	held_by_info
+	code_info
+	message_info
+	offending_value_info
+	module_info
+	revision_info
+	app_tag_info
+	internal_info
+	error_id_info
+	operation_info
+	reason_info
+)
+
+var vyErrInfoIdMap = map[vyErrInfoId]string{
+	related_path_info:    "related-path",
+	exit_status_info:     "exit-status",
+	held_by_info:         "held-by",
+	code_info:            "code",
+	message_info:         "message",
+	offending_value_info: "offending-value",
+	module_info:          "module",
+	revision_info:        "revision",
+	app_tag_info:         "app-tag",
+	internal_info:        "internal",
+	error_id_info:        "error-id",
+	operation_info:       "operation",
+	reason_info:          "reason",
+}
+
+func (i vyErrInfoId) String() string {
+	if s, ok := vyErrInfoIdMap[i]; ok {
+		return s
+	}
+	return ""
+}
+
+// WithRelatedPaths records further paths involved in the error, in
+// addition to Path, eg the leaves referenced by a failing must
+// statement.
+func (e *MgmtError) WithRelatedPaths(paths ...string) *MgmtError {
+	for _, p := range paths {
+		e.Info = append(e.Info, *NewMgmtErrorInfoTag(VyattaNamespace,
+			related_path_info.String(), p))
+	}
+	return e
+}
+
+// GetRelatedPaths returns the paths added with WithRelatedPaths.
+func (e *MgmtError) GetRelatedPaths() []string {
+	var paths []string
+	for _, t := range e.Info {
+		if t.XMLName.Space == VyattaNamespace &&
+			t.XMLName.Local == related_path_info.String() {
+			paths = append(paths, t.Value)
+		}
+	}
+	return paths
+}
+
+// Default numeric codes of the errors, by error-tag. These must not be
+// changed as they are used to identify errors in external systems.
+var defaultErrorCodes = map[string]int{
+	"in-use":                  1001,
+	"invalid-value":           1002,
+	"too-big":                 1003,
+	"missing-attribute":       1004,
+	"bad-attribute":           1005,
+	"unknown-attribute":       1006,
+	"missing-element":         1007,
+	"bad-element":             1008,
+	"unknown-element":         1009,
+	"unknown-namespace":       1010,
+	"access-denied":           1011,
+	"lock-denied":             1012,
+	"resource-denied":         1013,
+	"rollback-failed":         1014,
+	"data-exists":             1015,
+	"data-missing":            1016,
+	"operation-not-supported": 1017,
+	"operation-failed":        1018,
+	"partial-operation":       1019,
+	"malformed-message":       1020,
+}
+
+// setVyattaInfo sets the value of the Vyatta info tag id, replacing any
+// existing value.
+func (e *MgmtError) setVyattaInfo(id vyErrInfoId, value string) {
+	tag := NewMgmtErrorInfoTag(VyattaNamespace, id.String(), value)
+	for i, t := range e.Info {
+		if t.XMLName == tag.XMLName {
+			e.Info[i] = *tag
+			return
+		}
+	}
+	e.Info = append(e.Info, *tag)
+}
+
+// removeVyattaInfo removes any value of the Vyatta info tag id.
+func (e *MgmtError) removeVyattaInfo(id vyErrInfoId) {
+	info := e.Info[:0]
+	for _, t := range e.Info {
+		if t.XMLName.Space != VyattaNamespace ||
+			t.XMLName.Local != id.String() {
+			info = append(info, t)
+		}
+	}
+	e.Info = info
+}
+
+// WithCode records a numeric code for the error, eg for an external
+// ticketing system. It replaces any code already recorded.
+func (e *MgmtError) WithCode(code int) *MgmtError {
+	e.setVyattaInfo(code_info, strconv.Itoa(code))
+	return e
+}
+
+// GetCode returns the code recorded with WithCode, or otherwise the
+// default code for the error-tag. Zero is returned for an unknown tag.
+func (e *MgmtError) GetCode() int {
+	s := e.Info.FindMgmtErrorTag(VyattaNamespace, code_info.String())
+	if code, err := strconv.Atoi(s); err == nil {
+		return code
+	}
+	return defaultErrorCodes[e.Tag]
+}
+
+// SetOffendingValue records the configured value that failed
+// validation, eg for an invalid-value, bad-element or bad-attribute
+// error, so that an editor can present it to be corrected without
+// parsing the message. It replaces any value already recorded. The
+// WithValue constructors, eg NewInvalidValueApplicationErrorWithValue,
+// record it as the error is created.
+func (e *MgmtError) SetOffendingValue(value string) *MgmtError {
+	e.setVyattaInfo(offending_value_info, value)
+	return e
+}
+
+// GetOffendingValue returns the value recorded with SetOffendingValue,
+// and whether there is one.
+func (e *MgmtError) GetOffendingValue() (string, bool) {
+	for _, t := range e.Info {
+		if t.XMLName.Space == VyattaNamespace &&
+			t.XMLName.Local == offending_value_info.String() {
+			return t.Value, true
+		}
+	}
+	return "", false
+}
+
 func (e *MgmtError) setVyattaError(tag vyErrTag, apptag, path string, info *MgmtErrorInfo) error {
 	vyErr, ok := vyErrTable[tag]
 	if !ok {
@@ -137,6 +365,16 @@ func (e *ExecError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *ExecError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *ExecError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *ExecError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -151,12 +389,39 @@ func createExecError(err *MgmtError) *ExecError {
 // error when executing subtasks.
 //
 // path is the path of the subtask that was run
-// out is the output of the subtask
+// out is the output of the subtask; any invalid UTF-8 in it is replaced
 func NewExecError(path []string, out string) *ExecError {
 	err := newVyattaError(vyatta_operation_failed, exec_failed.String(),
 		pathutil.Pathstr(path), nil)
 	err.Message = out
-	return createExecError(err)
+	return createExecError(err.SanitizeUTF8())
+}
+
+// As NewExecError, additionally recording the exit status of the
+// subtask.
+func NewExecErrorWithStatus(path []string, out string, exitCode int) *ExecError {
+	info := MgmtErrorInfo{
+		*NewMgmtErrorInfoTag(VyattaNamespace, exit_status_info.String(),
+			strconv.Itoa(exitCode)),
+	}
+	err := newVyattaError(vyatta_operation_failed, exec_failed.String(),
+		pathutil.Pathstr(path), &info)
+	err.Message = out
+	return createExecError(err.SanitizeUTF8())
+}
+
+func (e *ExecError) GetMessage() string {
+	return e.decorate(e.message())
+}
+
+func (e *ExecError) message() string {
+	status := e.Info.FindMgmtErrorTag(VyattaNamespace,
+		exit_status_info.String())
+	if status == "" {
+		return e.Message
+	}
+	return formatMessage(ExecStatusMessage, e.Path, status,
+		e.Message)
 }
 
 type PathAmbiguousError struct {
@@ -168,16 +433,34 @@ func (e *PathAmbiguousError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *PathAmbiguousError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *PathAmbiguousError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *PathAmbiguousError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
 
+// GetMessage returns the path and its possible completions.
 func (e *PathAmbiguousError) GetMessage() string {
+	return e.decorate(e.message())
+}
+
+func (e *PathAmbiguousError) message() string {
 	var b bytes.Buffer
-	b.WriteString(ErrPath(pathutil.Makepath(e.Path)))
-	b.WriteString(" is ambiguous\n")
+	b.WriteString(formatMessage(PathAmbiguousMessage,
+		ErrPath(pathutil.Makepath(e.Path))))
+	b.WriteString("\n")
 
-	b.WriteString("EZ9: Possible completions:\n")
+	b.WriteString("EZ9: ")
+	b.WriteString(formatMessage(PossibleCompletionsMessage))
+	b.WriteString("\n")
 	pathMap := make(map[string]string, len(e.Info))
 	for _, elem := range e.Info {
 		pathMap[elem.XMLName.Local] = elem.Value
@@ -204,23 +487,28 @@ func (e *PathAmbiguousError) Error() string {
 	}
 	natsort.Sort(mlist)
 
-	var b bytes.Buffer
-	b.WriteString(strings.Title(e.Severity))
-	b.WriteString(error_msg_separator)
+	var msg bytes.Buffer
 	if len(e.Path) == 0 {
-		b.WriteString("Ambiguous command")
+		msg.WriteString("Ambiguous command")
 	} else {
-		b.WriteString(e.Path)
-		b.WriteString(error_msg_separator)
-		b.WriteString("Ambiguous path")
+		msg.WriteString("Ambiguous path")
 	}
-	b.WriteString(", could be one of: ")
+	msg.WriteString(", could be one of: ")
 	for i, m := range mlist {
 		if i > 0 {
-			b.WriteString(", ")
+			msg.WriteString(", ")
 		}
-		b.WriteString(m)
+		msg.WriteString(m)
+	}
+
+	var b bytes.Buffer
+	b.WriteString(titledSeverity(e.GetSeverity()))
+	b.WriteString(error_msg_separator)
+	if len(e.Path) != 0 {
+		b.WriteString(e.Path)
+		b.WriteString(error_msg_separator)
 	}
+	b.WriteString(e.decorate(msg.String()))
 	return b.String()
 }
 
@@ -245,3 +533,291 @@ func NewPathAmbiguousError(path []string, matches map[string]string) *PathAmbigu
 		pathutil.Pathstr(path), &info)
 	return createPathAmbigError(err)
 }
+
+const msg_path_invalid = "Path is invalid"
+
+type InvalidPathError struct {
+	*MgmtError
+}
+
+func (e *InvalidPathError) UnmarshalJSON(value []byte) error {
+	e.MgmtError = newMgmtError()
+	return json.Unmarshal(value, e.MgmtError)
+}
+
+func (e *InvalidPathError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *InvalidPathError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
+func (e *InvalidPathError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return enc.Encode(e.MgmtError)
+}
+
+func (e *InvalidPathError) GetMessage() string {
+	return e.decorate(e.message())
+}
+
+func (e *InvalidPathError) message() string {
+	return formatMessage(InvalidPathMessage, e.Path, e.Message)
+}
+
+func createInvalidPathError(err *MgmtError) *InvalidPathError {
+	return &InvalidPathError{
+		MgmtError: err,
+	}
+}
+
+// A custom wrapper of a standard "invalid value" to represent a
+// configuration path that could not be parsed, as opposed to one
+// containing an unknown element.
+//
+// path is the path that is invalid
+func NewInvalidPathError(path string) *InvalidPathError {
+	err := newVyattaError(vyatta_invalid_value, path_invalid.String(),
+		path, nil)
+	err.Message = msg_path_invalid
+	return createInvalidPathError(err)
+}
+
+const msg_schema_mismatch = "Doesn't match schema"
+
+type SchemaMismatchError struct {
+	*MgmtError
+}
+
+func (e *SchemaMismatchError) UnmarshalJSON(value []byte) error {
+	e.MgmtError = newMgmtError()
+	return json.Unmarshal(value, e.MgmtError)
+}
+
+func (e *SchemaMismatchError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *SchemaMismatchError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
+func (e *SchemaMismatchError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return enc.Encode(e.MgmtError)
+}
+
+func createSchemaMismatchError(err *MgmtError) *SchemaMismatchError {
+	return &SchemaMismatchError{
+		MgmtError: err,
+	}
+}
+
+// A custom wrapper of a standard "invalid value" to represent
+// configuration that does not match the schema, as opposed to an
+// unknown element, so that consumers can distinguish the two.
+//
+// path is the path that does not match the schema
+func NewSchemaMismatchError(path string) *SchemaMismatchError {
+	err := newVyattaError(vyatta_invalid_value, schema_mismatch.String(),
+		path, nil)
+	err.Message = msg_schema_mismatch
+	return createSchemaMismatchError(err)
+}
+
+type WhenViolationError struct {
+	*MgmtError
+}
+
+func (e *WhenViolationError) UnmarshalJSON(value []byte) error {
+	e.MgmtError = newMgmtError()
+	return json.Unmarshal(value, e.MgmtError)
+}
+
+func (e *WhenViolationError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *WhenViolationError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
+func (e *WhenViolationError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return enc.Encode(e.MgmtError)
+}
+
+func createWhenViolationError(err *MgmtError) *WhenViolationError {
+	return &WhenViolationError{
+		MgmtError: err,
+	}
+}
+
+// When a NETCONF operation would result in configuration data where
+// the condition of a "when" statement is false. RFC7950 does not define
+// an app-tag for this, so it is distinguished from a MustViolationError
+// by a Vyatta app-tag.
+func NewWhenViolationError() *WhenViolationError {
+	return createWhenViolationError(newVyattaError(vyatta_operation_failed,
+		when_violation.String(), needNodePath, nil))
+}
+
+func (e *WhenViolationError) GetMessage() string {
+	return e.decorate(e.message())
+}
+
+func (e *WhenViolationError) message() string {
+	return relatedPathsMessage(e.MgmtError)
+}
+
+type ConfigPathInvalidError struct {
+	*MgmtError
+}
+
+func (e *ConfigPathInvalidError) UnmarshalJSON(value []byte) error {
+	e.MgmtError = newMgmtError()
+	return json.Unmarshal(value, e.MgmtError)
+}
+
+func (e *ConfigPathInvalidError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *ConfigPathInvalidError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
+func (e *ConfigPathInvalidError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return enc.Encode(e.MgmtError)
+}
+
+func createConfigPathInvalidError(err *MgmtError) *ConfigPathInvalidError {
+	return &ConfigPathInvalidError{
+		MgmtError: err,
+	}
+}
+
+// When a path given to set configuration is rejected, reported as the
+// CLI does, eg:
+//
+//	Configuration path: interfaces dataplane [dp0s99] is not valid
+//
+// path is the configuration path, and reason replaces "is not valid" if
+// it is not empty, eg "has invalid prefix 'x'".
+func NewConfigPathInvalidError(path []string, reason string) *ConfigPathInvalidError {
+	err := newVyattaError(vyatta_invalid_value,
+		config_path_invalid.String(), pathutil.Pathstr(path), nil)
+	if reason != "" {
+		err.setVyattaInfo(reason_info, reason)
+	}
+	err.Message = configPathString(path, reason)
+	return createConfigPathInvalidError(err)
+}
+
+func (e *ConfigPathInvalidError) GetMessage() string {
+	return e.decorate(e.message())
+}
+
+func (e *ConfigPathInvalidError) message() string {
+	return e.SetErrorString()
+}
+
+// SetErrorString returns the error as reported by the CLI when setting
+// configuration.
+func (e *ConfigPathInvalidError) SetErrorString() string {
+	reason := e.Info.FindMgmtErrorTag(VyattaNamespace, reason_info.String())
+	return configPathString(pathutil.Makepath(e.Path), reason)
+}
+
+// App-tag for a bad attribute in the Vyatta transport framing
+const transportBadAttrAppTag = "transport-bad-attribute"
+
+func init() {
+	if err := RegisterVyattaError(transport.String(), bad_attribute.String(),
+		transportBadAttrAppTag, createTransportBadAttrError); err != nil {
+		panic(err)
+	}
+}
+
+type TransportBadAttrError struct {
+	*MgmtError
+}
+
+func (e *TransportBadAttrError) UnmarshalJSON(value []byte) error {
+	e.MgmtError = newMgmtError()
+	return json.Unmarshal(value, e.MgmtError)
+}
+
+func (e *TransportBadAttrError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *TransportBadAttrError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
+func (e *TransportBadAttrError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	return enc.Encode(e.MgmtError)
+}
+
+func createTransportBadAttrError(err *MgmtError) *TransportBadAttrError {
+	return &TransportBadAttrError{
+		MgmtError: err,
+	}
+}
+
+// Transport error when an attribute value in the Vyatta transport
+// framing is not correct. RFC6241 does not define attribute errors at
+// the transport layer, so this is distinguished by a Vyatta app-tag.
+//
+// badAttr is the name of the attribute with bad value
+// badElem is the name of the framing element that contains the
+// attribute with the bad value, or "" if it is not known
+func NewTransportBadAttrError(badAttr, badElem string) *TransportBadAttrError {
+	return createTransportBadAttrError(newAttrError(bad_attribute,
+		transport.String(), transportBadAttrAppTag, badAttr, badElem))
+}
+
+// WithModule records the YANG module, and its revision if known, that
+// defines the data the error is about, so that an error can be
+// interpreted against the schema that produced it. It replaces any
+// module already recorded. Neither is present unless set.
+func (e *MgmtError) WithModule(name, revision string) *MgmtError {
+	e.setVyattaInfo(module_info, name)
+	if revision == "" {
+		e.removeVyattaInfo(revision_info)
+	} else {
+		e.setVyattaInfo(revision_info, revision)
+	}
+	return e
+}
+
+// GetModule returns the module and revision recorded with WithModule,
+// which are empty if not recorded.
+func (e *MgmtError) GetModule() (name, revision string) {
+	return e.Info.FindMgmtErrorTag(VyattaNamespace, module_info.String()),
+		e.Info.FindMgmtErrorTag(VyattaNamespace, revision_info.String())
+}
+
+// MarkInternal records that the error is an internal failure, eg a
+// failed assertion, which should be logged but not shown to end users.
+// Such errors are removed by MgmtErrorList.ClientFacing; the marker is
+// kept when the error is marshalled so that it reaches the logs.
+func (e *MgmtError) MarkInternal() *MgmtError {
+	e.setVyattaInfo(internal_info, "true")
+	return e
+}
+
+// IsInternal reports whether the error was marked with MarkInternal.
+func (e *MgmtError) IsInternal() bool {
+	return e.Info.FindMgmtErrorTag(VyattaNamespace,
+		internal_info.String()) == "true"
+}
@@ -8,7 +8,15 @@
 package mgmterror
 
 import (
+	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"html"
+	"reflect"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/kr/pretty"
 )
 
 func ExampleExecError() {
@@ -20,6 +28,50 @@ func ExampleExecError() {
 	// Error: /usr/bin/app: core dumped
 }
 
+func ExampleExecError_withStatus() {
+	path := []string{"usr", "bin", "app"}
+	err := NewExecErrorWithStatus(path, "core dumped", 139)
+	fmt.Println(err.GetMessage())
+
+	//Output:
+	// subtask /usr/bin/app exited 139: core dumped
+}
+
+func TestExecErrorInvalidUTF8(t *testing.T) {
+	// "échec" in Latin-1
+	err := NewExecErrorWithStatus([]string{"usr", "bin", "app"},
+		"\xe9chec", 1)
+	if err.Message != "\uFFFDchec" {
+		t.Errorf("Invalid UTF-8 not replaced: %q", err.Message)
+	}
+	if !utf8.ValidString(err.Error()) {
+		t.Errorf("Invalid UTF-8 in error string: %q", err.Error())
+	}
+
+	marshal, e := json.Marshal(err)
+	if e != nil {
+		t.Fatalf("Marshal error: %v", e)
+	}
+	if !json.Valid(marshal) {
+		t.Errorf("Invalid JSON: %s", marshal)
+	}
+	unmarshal := ExecError{}
+	if e := json.Unmarshal(marshal, &unmarshal); e != nil {
+		t.Fatalf("Unmarshal error: %v", e)
+	}
+	cmpMgmtError(t, err.MgmtError, unmarshal.MgmtError)
+
+	other := newMgmtError()
+	other.Path = "/a/\xff"
+	other.Info = append(other.Info,
+		*NewMgmtErrorInfoTag("", "bad-element", "\xfe\xff"))
+	other.SanitizeUTF8()
+	if other.Path != "/a/\uFFFD" || other.Info[0].Value != "\uFFFD" {
+		t.Errorf("Invalid UTF-8 not replaced: %q, %q", other.Path,
+			other.Info[0].Value)
+	}
+}
+
 func ExamplePathAmbiguousError() {
 	path := []string{"s"}
 	matches := map[string]string{
@@ -46,3 +98,399 @@ func ExamplePathAmbiguousError_command() {
 	//Output:
 	// Error: Ambiguous command, could be one of: save, set, show
 }
+
+func TestConfigPathInvalidError(t *testing.T) {
+	path := []string{"interfaces", "dataplane", "dp0s99"}
+	tests := []struct {
+		name     string
+		reason   string
+		expected string
+	}{
+		{"default", "",
+			"Configuration path: interfaces dataplane [dp0s99] is not valid"},
+		{"reason", "has invalid prefix 'x'",
+			"Configuration path: interfaces dataplane [dp0s99] " +
+				"has invalid prefix 'x'"},
+	}
+	for _, test := range tests {
+		err := NewConfigPathInvalidError(path, test.reason)
+		if s := err.SetErrorString(); s != test.expected {
+			t.Errorf("%s: unexpected set error\n  expect: %s\n  got:    %s",
+				test.name, test.expected, s)
+		}
+		if msg := err.GetMessage(); msg != test.expected {
+			t.Errorf("%s: unexpected message\n  expect: %s\n  got:    %s",
+				test.name, test.expected, msg)
+		}
+
+		var list MgmtErrorList
+		list.MgmtErrorListAppend(err)
+		b, e := json.Marshal(list)
+		if e != nil {
+			t.Fatalf("%s: marshal error: %v", test.name, e)
+		}
+		var decoded MgmtErrorList
+		if e := json.Unmarshal(b, &decoded); e != nil {
+			t.Fatalf("%s: unmarshal error: %v", test.name, e)
+		}
+		if !reflect.DeepEqual(list, decoded) {
+			t.Errorf("%s: unexpected error decoded", test.name)
+			t.Logf("Expected: %# v", pretty.Formatter(list))
+			t.Logf("Result:   %# v", pretty.Formatter(decoded))
+		}
+	}
+}
+
+func TestInvalidPathError(t *testing.T) {
+	const path = "/interfaces/dataplane/dp0s1/address"
+	vyerr := NewInvalidPathError(path)
+
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(vyerr)
+	marshal, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatalf("Marshal error: %v\n", err)
+	}
+	var unmarshal MgmtErrorList
+	if err := json.Unmarshal(marshal, &unmarshal); err != nil {
+		t.Fatalf("Unmarshal error: %v\n", err)
+	}
+	if !reflect.DeepEqual(errs, unmarshal) {
+		t.Errorf("Failed JSON marshal/unmarshal")
+		t.Logf("Expected: %# v", pretty.Formatter(errs))
+		t.Logf("Result:   %# v", pretty.Formatter(unmarshal))
+	}
+	if _, ok := unmarshal.Errors()[0].(*InvalidPathError); !ok {
+		t.Errorf("Unmarshalled to %T", unmarshal.Errors()[0])
+	}
+
+	const expMsg = path + ": Path is invalid"
+	if msg := vyerr.GetMessage(); msg != expMsg {
+		t.Errorf("Unexpected message\n  expect: %s\n  got:    %s",
+			expMsg, msg)
+	}
+
+	verifyXmlMarshal(t, vyerr, `<rpc-error xmlns="`+netconf_namespace+`">
+	<error-type>application</error-type>
+	<error-tag>invalid-value</error-tag>
+	<error-severity>error</error-severity>
+	<error-app-tag>path-invalid</error-app-tag>
+	<error-path>`+path+`</error-path>
+	<error-message>Path is invalid</error-message>
+</rpc-error>`)
+}
+
+func TestSchemaMismatchError(t *testing.T) {
+	const path = "/interfaces/dataplane/dp0s1/foo"
+	vyerr := NewSchemaMismatchError(path)
+
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(vyerr, NewUnknownElementApplicationError("foo"))
+	marshal, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatalf("Marshal error: %v\n", err)
+	}
+	var unmarshal MgmtErrorList
+	if err := json.Unmarshal(marshal, &unmarshal); err != nil {
+		t.Fatalf("Unmarshal error: %v\n", err)
+	}
+	if !reflect.DeepEqual(errs, unmarshal) {
+		t.Errorf("Failed JSON marshal/unmarshal")
+		t.Logf("Expected: %# v", pretty.Formatter(errs))
+		t.Logf("Result:   %# v", pretty.Formatter(unmarshal))
+	}
+	if _, ok := unmarshal.Errors()[0].(*SchemaMismatchError); !ok {
+		t.Errorf("Unmarshalled to %T", unmarshal.Errors()[0])
+	}
+	if _, ok := unmarshal.Errors()[1].(*UnknownElementApplicationError); !ok {
+		t.Errorf("Unknown element unmarshalled to %T",
+			unmarshal.Errors()[1])
+	}
+
+	if msg := vyerr.GetMessage(); msg != "Doesn't match schema" {
+		t.Errorf("Unexpected message: %s", msg)
+	}
+
+	verifyXmlMarshal(t, vyerr, `<rpc-error xmlns="`+netconf_namespace+`">
+	<error-type>application</error-type>
+	<error-tag>invalid-value</error-tag>
+	<error-severity>error</error-severity>
+	<error-app-tag>schema-mismatch</error-app-tag>
+	<error-path>`+path+`</error-path>
+	<error-message>Doesn&#39;t match schema</error-message>
+</rpc-error>`)
+}
+
+func TestTransportBadAttrError(t *testing.T) {
+	vyerr := NewTransportBadAttrError("chunk-size", "frame")
+
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(vyerr)
+	marshal, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatalf("Marshal error: %v\n", err)
+	}
+	var unmarshal MgmtErrorList
+	if err := json.Unmarshal(marshal, &unmarshal); err != nil {
+		t.Fatalf("Unmarshal error: %v\n", err)
+	}
+	if !reflect.DeepEqual(errs, unmarshal) {
+		t.Errorf("Failed JSON marshal/unmarshal")
+		t.Logf("Expected: %# v", pretty.Formatter(errs))
+		t.Logf("Result:   %# v", pretty.Formatter(unmarshal))
+	}
+	if _, ok := unmarshal.Errors()[0].(*TransportBadAttrError); !ok {
+		t.Errorf("Unmarshalled to %T", unmarshal.Errors()[0])
+	}
+	if !IsVyattaError(unmarshal.Errors()[0]) {
+		t.Errorf("Not classified as a Vyatta error")
+	}
+
+	verifyXmlMarshal(t, vyerr, `<rpc-error xmlns="`+netconf_namespace+`">
+	<error-type>transport</error-type>
+	<error-tag>bad-attribute</error-tag>
+	<error-severity>error</error-severity>
+	<error-app-tag>transport-bad-attribute</error-app-tag>
+	<error-message>`+html.EscapeString(msg_nc_bad_attribute)+`</error-message>
+	<error-info>
+		<bad-attribute>chunk-size</bad-attribute>
+		<bad-element>frame</bad-element>
+	</error-info>
+</rpc-error>`)
+
+	noElem := NewTransportBadAttrError("chunk-size", "")
+	if len(noElem.Info) != 1 ||
+		noElem.Info.FindMgmtErrorTag("", bad_attribute_info.String()) !=
+			"chunk-size" {
+		t.Errorf("Unexpected info without bad-element: %v", noElem.Info)
+	}
+
+	// Only the registered app-tag allows a transport bad-attribute
+	if err := newMgmtError().setNcError(bad_attribute, transport.String(),
+		"", "", nil); err != invalid_error_tag_type {
+		t.Errorf("Unexpected error for unregistered app-tag: %v", err)
+	}
+}
+
+func TestRegisterVyattaErrorInvalid(t *testing.T) {
+	tests := []struct {
+		name             string
+		typ, tag, apptag string
+		create           interface{}
+	}{
+		{"bad type", "session", "in-use", "foo", createExecError},
+		{"no tag", "rpc", "", "foo", createExecError},
+		{"no app-tag", "rpc", "in-use", "", createExecError},
+		{"not a function", "rpc", "in-use", "foo", "createExecError"},
+		{"bad function", "rpc", "in-use", "foo", func(s string) error { return nil }},
+		{"duplicate", transport.String(), bad_attribute.String(),
+			transportBadAttrAppTag, createTransportBadAttrError},
+	}
+	for _, test := range tests {
+		if err := RegisterVyattaError(test.typ, test.tag, test.apptag,
+			test.create); err == nil {
+			t.Errorf("%s: registration unexpectedly succeeded", test.name)
+		}
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	inUse := NewInUseApplicationError()
+	if len(inUse.Info) != 0 {
+		t.Errorf("Unexpected info by default: %v", inUse.Info)
+	}
+	if code := inUse.GetCode(); code != 1001 {
+		t.Errorf("Unexpected default code: %d", code)
+	}
+
+	inUse.WithCode(42).WithCode(4242)
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(inUse)
+	marshal, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatalf("Marshal error: %v\n", err)
+	}
+	var unmarshal MgmtErrorList
+	if err := json.Unmarshal(marshal, &unmarshal); err != nil {
+		t.Fatalf("Unmarshal error: %v\n", err)
+	}
+	me, _ := AsMgmtError(unmarshal.Errors()[0].(Formattable))
+	if code := me.GetCode(); code != 4242 {
+		t.Errorf("Unexpected code: %d", code)
+	}
+	if len(me.Info) != 1 {
+		t.Errorf("Unexpected info: %v", me.Info)
+	}
+
+	unknown := newMgmtError()
+	unknown.Tag = "not-a-tag"
+	if code := unknown.GetCode(); code != 0 {
+		t.Errorf("Unexpected code for unknown tag: %d", code)
+	}
+}
+
+func TestOffendingValue(t *testing.T) {
+	errs := []*MgmtError{
+		NewInvalidValueApplicationError().MgmtError,
+		NewBadElementApplicationError("mtu").MgmtError,
+		NewBadAttrApplicationError("operation", "config").MgmtError,
+	}
+	for _, err := range errs {
+		if _, ok := err.GetOffendingValue(); ok {
+			t.Errorf("%s: unexpected offending value", err.Tag)
+		}
+		err.SetOffendingValue("").SetOffendingValue("99999")
+
+		marshal, e := json.Marshal(err)
+		if e != nil {
+			t.Fatalf("%s: marshal error: %v", err.Tag, e)
+		}
+		unmarshal := newMgmtError()
+		if e := json.Unmarshal(marshal, unmarshal); e != nil {
+			t.Fatalf("%s: unmarshal error: %v", err.Tag, e)
+		}
+		value, ok := unmarshal.GetOffendingValue()
+		if !ok || value != "99999" {
+			t.Errorf("%s: unexpected offending value %q", err.Tag, value)
+		}
+		count := 0
+		for _, tag := range unmarshal.Info {
+			if tag.XMLName.Local == "offending-value" {
+				count++
+			}
+		}
+		if count != 1 {
+			t.Errorf("%s: %d offending values recorded", err.Tag, count)
+		}
+	}
+
+	empty := NewInvalidValueApplicationError().SetOffendingValue("")
+	if value, ok := empty.GetOffendingValue(); !ok || value != "" {
+		t.Errorf("Empty offending value not found: %q, %v", value, ok)
+	}
+}
+
+func TestOffendingValueConstructors(t *testing.T) {
+	pattern, err := NewConstraintErrorWithValue("/system/host-name",
+		"invalid-value", "", "Must match [a-z]+", "Host1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"invalid-value protocol",
+			NewInvalidValueProtocolErrorWithValue("1"), "1"},
+		{"invalid-value application",
+			NewInvalidValueApplicationErrorWithValue("2"), "2"},
+		{"bad-attribute rpc",
+			NewBadAttrRpcErrorWithValue("operation", "config", "3"), "3"},
+		{"bad-attribute protocol",
+			NewBadAttrProtocolErrorWithValue("operation", "config", "4"),
+			"4"},
+		{"bad-attribute application",
+			NewBadAttrApplicationErrorWithValue("operation", "config",
+				"5"), "5"},
+		{"bad-element protocol",
+			NewBadElementProtocolErrorWithValue("mtu", "6"), "6"},
+		{"bad-element application",
+			NewBadElementApplicationErrorWithValue("mtu", "7"), "7"},
+		{"pattern", pattern.(error), "Host1"},
+	}
+	for _, test := range tests {
+		me, _ := AsMgmtError(test.err.(Formattable))
+		if value, ok := me.GetOffendingValue(); !ok ||
+			value != test.expected {
+			t.Errorf("%s: unexpected offending value %q", test.name, value)
+		}
+	}
+}
+
+func TestWithModule(t *testing.T) {
+	err := NewMustViolationError().MgmtError
+	if name, rev := err.GetModule(); name != "" || rev != "" {
+		t.Errorf("Unexpected default module: %q %q", name, rev)
+	}
+	if len(err.Info) != 0 {
+		t.Errorf("Unexpected info by default: %v", err.Info)
+	}
+
+	err.WithModule("vyatta-system-v1", "2015-08-05")
+	marshal, e := xml.Marshal(err)
+	if e != nil {
+		t.Fatalf("Marshal error: %v", e)
+	}
+	unmarshal := newMgmtError()
+	if e := xml.Unmarshal(marshal, unmarshal); e != nil {
+		t.Fatalf("Unmarshal error: %v", e)
+	}
+	name, rev := unmarshal.GetModule()
+	if name != "vyatta-system-v1" || rev != "2015-08-05" {
+		t.Errorf("Unexpected module: %q %q", name, rev)
+	}
+
+	err.WithModule("vyatta-system-v2", "")
+	if name, rev := err.GetModule(); name != "vyatta-system-v2" || rev != "" {
+		t.Errorf("Unexpected replaced module: %q %q", name, rev)
+	}
+	if len(err.Info) != 1 {
+		t.Errorf("Unexpected info after replacing module: %v", err.Info)
+	}
+}
+
+func TestWhenViolationError(t *testing.T) {
+	when := NewWhenViolationError()
+	when.Path = "/interfaces/dataplane/dp0s1/mtu"
+	when.WithRelatedPaths("/interfaces/dataplane/dp0s1/address")
+
+	var errs MgmtErrorList
+	errs.MgmtErrorListAppend(when, NewMustViolationError())
+	marshal, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatalf("Marshal error: %v\n", err)
+	}
+	var unmarshal MgmtErrorList
+	if err := json.Unmarshal(marshal, &unmarshal); err != nil {
+		t.Fatalf("Unmarshal error: %v\n", err)
+	}
+	if !reflect.DeepEqual(errs, unmarshal) {
+		t.Errorf("Failed JSON marshal/unmarshal")
+		t.Logf("Expected: %# v", pretty.Formatter(errs))
+		t.Logf("Result:   %# v", pretty.Formatter(unmarshal))
+	}
+	if _, ok := unmarshal.Errors()[0].(*WhenViolationError); !ok {
+		t.Errorf("Unmarshalled to %T", unmarshal.Errors()[0])
+	}
+
+	expMsg := msg_yang_operation_failed +
+		" Related paths: /interfaces/dataplane/dp0s1/address"
+	if msg := when.GetMessage(); msg != expMsg {
+		t.Errorf("Unexpected message: %s", msg)
+	}
+}
+
+func TestGetXPathKind(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    *MgmtError
+		kind   XPathKind
+		isKind bool
+	}{
+		{"must", NewMustViolationError().MgmtError, XPathMust, true},
+		{"when", NewWhenViolationError().MgmtError, XPathWhen, true},
+		{"other", NewTooManyElementsError("/foo").MgmtError, 0, false},
+		{"other tag", NewInUseApplicationError().
+			WithAppTag(must_violation.String()), 0, false},
+	}
+	for _, test := range tests {
+		kind, ok := test.err.GetXPathKind()
+		if ok != test.isKind || (ok && kind != test.kind) {
+			t.Errorf("%s: unexpected kind %s, %v", test.name, kind, ok)
+		}
+	}
+	if XPathMust.String() != "must" || XPathWhen.String() != "when" {
+		t.Errorf("Unexpected kind names: %s, %s", XPathMust, XPathWhen)
+	}
+}
@@ -13,6 +13,8 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"errors"
+	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -124,6 +126,20 @@ const (
 	msg_yang_bad_attribute    = `An attribute value is not correct; e.g., wrong type, out of range, pattern mismatch.`
 )
 
+// yangErrTable maps the error-tag and error-app-tag of each RFC6020
+// Section 13 error to the function creating its type:
+//
+//	operation-failed  data-not-unique    NonUniqueError
+//	operation-failed  too-many-elements  TooManyElementsError
+//	operation-failed  too-few-elements   TooFewElementsError
+//	operation-failed  must-violation     MustViolationError
+//	data-missing      instance-required  InstanceRequiredError
+//	data-missing      missing-choice     MissingChoiceError
+//	bad-attribute     missing-instance   InsertFailedError
+//
+// Note that "instance-required" (a reference to a non-existing
+// instance) and "missing-instance" (an insert relative to a
+// non-existing entry) are distinguished by the error-tag as well.
 var yangErrTable map[yerrtag]yangErrTag
 
 // TODO: add name for when converted to DBusError
@@ -159,20 +175,27 @@ func init() {
 	}
 }
 
-func getYangError(err *MgmtError) error {
+// yangErrorCreator returns the function creating the YANG error type
+// matching err, if there is one.
+func yangErrorCreator(err *MgmtError) (interface{}, bool) {
 	tag, ok := errtagmap[err.Tag]
 	if !ok {
-		return nil
+		return nil, false
 	}
 	errtag, ok := yangErrTable[tag]
 	if !ok {
-		return nil
+		return nil, false
 	}
 	apptag, ok := yerrapptagmap[err.AppTag]
 	if !ok {
-		return nil
+		return nil, false
 	}
 	fn, ok := errtag.apptag[apptag]
+	return fn, ok
+}
+
+func getYangError(err *MgmtError) error {
+	fn, ok := yangErrorCreator(err)
 	if !ok {
 		return nil
 	}
@@ -185,11 +208,21 @@ type yangErrInfoId uint
 const (
 	non_unique_info yangErrInfoId = iota
 	missing_choice_info
+	required_instance_info
+	element_count_info
+	max_elements_info
+	min_elements_info
+	insert_value_info
 )
 
 var yangErrInfoIdMap = map[yangErrInfoId]string{
-	non_unique_info:     "non-unique",
-	missing_choice_info: "missing-choice",
+	non_unique_info:        "non-unique",
+	missing_choice_info:    "missing-choice",
+	required_instance_info: "required-instance",
+	element_count_info:     "element-count",
+	max_elements_info:      "max-elements",
+	min_elements_info:      "min-elements",
+	insert_value_info:      "insert-value",
 }
 
 func (i yangErrInfoId) String() string {
@@ -240,6 +273,9 @@ func newYangError(
 	info *MgmtErrorInfo,
 ) *MgmtError {
 
+	if PanicOnRelativePath && !isAbsolutePath(path) {
+		panic(fmt.Errorf("error-path %s is not absolute", path))
+	}
 	e := newMgmtError()
 	if err := e.setYangError(tag, apptag, path, yangPath, info); err != nil {
 		panic(err)
@@ -258,26 +294,53 @@ func (e *NonUniqueError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *NonUniqueError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *NonUniqueError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *NonUniqueError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
 
+// RelativeTo returns a copy of the error with its paths relative to
+// base, as MgmtError.RelativeTo.
+func (e *NonUniqueError) RelativeTo(base string) *NonUniqueError {
+	return createNonUniqueError(e.MgmtError.RelativeTo(base))
+}
+
+// NonUniquePaths returns the paths of the non-unique leaves, in the
+// order they were given to NewNonUniqueError. The order is preserved
+// through marshalling, as consumers may present the paths positionally.
+func (e *NonUniqueError) NonUniquePaths() []string {
+	var paths []string
+	for _, t := range e.Info {
+		if t.XMLName.Space == yang_namespace &&
+			t.XMLName.Local == non_unique_info.String() {
+			paths = append(paths, t.Value)
+		}
+	}
+	return paths
+}
+
 func (e *NonUniqueError) Error() string {
-	if len(e.Info) < 2 {
+	nonUnique := e.NonUniquePaths()
+	if len(nonUnique) < 2 {
 		return e.MgmtError.Error()
 	}
 	var b bytes.Buffer
-	b.WriteString(strings.Title(e.Severity))
+	b.WriteString(titledSeverity(e.GetSeverity()))
 	b.WriteString(error_msg_separator)
 	b.WriteString(e.Path)
 	b.WriteString(error_msg_separator)
-	b.WriteString("Non-unique paths ")
-	for i, p := range e.Info {
-		if i > 0 {
-			b.WriteString(", ")
-		}
-		b.WriteString(strings.TrimPrefix(p.Value, e.Path+"/"))
-	}
+	rel := e.RelativeTo(e.Path)
+	b.WriteString(e.decorate(formatMessage(NonUniquePathsMessage,
+		strings.Join(rel.NonUniquePaths(), ", "))))
 	return b.String()
 }
 
@@ -320,6 +383,16 @@ func (e *TooManyElementsError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *TooManyElementsError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *TooManyElementsError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *TooManyElementsError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -341,6 +414,23 @@ func NewTooManyElementsError(path string) *TooManyElementsError {
 		too_many_elements.String(), path, noYangPath, nil))
 }
 
+// As NewTooManyElementsError, additionally recording the number of
+// entries present and the max-elements limit of the list.
+func NewTooManyElementsErrorWithCount(path string, count, limit int) *TooManyElementsError {
+	info := elementCountInfo(max_elements_info, count, limit)
+	return createTooManyElementsError(newYangError(yang_operation_failed,
+		too_many_elements.String(), path, noYangPath, &info))
+}
+
+func (e *TooManyElementsError) GetMessage() string {
+	return e.decorate(e.message())
+}
+
+func (e *TooManyElementsError) message() string {
+	return elementCountMessage(e.MgmtError, max_elements_info,
+		TooManyElementsMessage)
+}
+
 // RFC6020 Sect 13.3
 // Error Message for Data That Violates a min-elements Statement
 type TooFewElementsError struct {
@@ -352,6 +442,16 @@ func (e *TooFewElementsError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *TooFewElementsError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *TooFewElementsError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *TooFewElementsError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -373,6 +473,44 @@ func NewTooFewElementsError(path string) *TooFewElementsError {
 		too_few_elements.String(), path, noYangPath, nil))
 }
 
+// As NewTooFewElementsError, additionally recording the number of
+// entries present and the min-elements limit of the list.
+func NewTooFewElementsErrorWithCount(path string, count, limit int) *TooFewElementsError {
+	info := elementCountInfo(min_elements_info, count, limit)
+	return createTooFewElementsError(newYangError(yang_operation_failed,
+		too_few_elements.String(), path, noYangPath, &info))
+}
+
+func (e *TooFewElementsError) GetMessage() string {
+	return e.decorate(e.message())
+}
+
+func (e *TooFewElementsError) message() string {
+	return elementCountMessage(e.MgmtError, min_elements_info,
+		TooFewElementsMessage)
+}
+
+func elementCountInfo(limitId yangErrInfoId, count, limit int) MgmtErrorInfo {
+	return MgmtErrorInfo{
+		*NewMgmtErrorInfoTag(yang_namespace,
+			element_count_info.String(), strconv.Itoa(count)),
+		*NewMgmtErrorInfoTag(yang_namespace,
+			limitId.String(), strconv.Itoa(limit)),
+	}
+}
+
+// elementCountMessage describes the entry count and limit recorded in
+// e, falling back to the generic message when they are not present.
+func elementCountMessage(e *MgmtError, limitId yangErrInfoId, kind MessageKind) string {
+	count := e.Info.FindMgmtErrorTag(yang_namespace,
+		element_count_info.String())
+	limit := e.Info.FindMgmtErrorTag(yang_namespace, limitId.String())
+	if count == "" || limit == "" {
+		return e.Message
+	}
+	return formatMessage(kind, count, limit)
+}
+
 // RFC6020 Sect 13.4
 // Error Message for Data That Violates a must Statement
 type MustViolationError struct {
@@ -384,6 +522,16 @@ func (e *MustViolationError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *MustViolationError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *MustViolationError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *MustViolationError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -401,6 +549,102 @@ func NewMustViolationError() *MustViolationError {
 		must_violation.String(), needNodePath, noYangPath, nil))
 }
 
+// relatedPathsMessage adds the related paths recorded in e, if any, to
+// its message.
+func relatedPathsMessage(e *MgmtError) string {
+	paths := e.GetRelatedPaths()
+	if len(paths) == 0 {
+		return e.Message
+	}
+	return formatMessage(RelatedPathsMessage, e.Message,
+		strings.Join(paths, ", "))
+}
+
+func (e *MustViolationError) GetMessage() string {
+	return e.decorate(e.message())
+}
+
+func (e *MustViolationError) message() string {
+	return relatedPathsMessage(e.MgmtError)
+}
+
+// NewConstraintError creates the error for data failing a YANG
+// constraint, eg a must, pattern or range statement, whose error-tag,
+// error-app-tag and error-message are given by the schema. The result
+// is the specific error type for the tag and app-tag, eg a
+// *MustViolationError for operation-failed and must-violation, or
+// otherwise the NETCONF error type for the tag. Like all the error
+// types, it is also an error.
+//
+// If message is empty, the default message for the error is used. An
+// error is returned if tag is not a NETCONF error-tag defined for
+// application errors.
+func NewConstraintError(path, tag, appTag, message string) (Formattable, error) {
+	tagId, ok := ncerrtagmap[tag]
+	if !ok {
+		return nil, fmt.Errorf("%v: %s", invalid_error_tag, tag)
+	}
+	e := newMgmtError()
+	if err := e.setNcError(tagId, application.String(), appTag, path,
+		nil); err != nil {
+		return nil, fmt.Errorf("%v: %s", err, tag)
+	}
+	if message != "" {
+		e.Message = message
+	} else if _, ok := yangErrorCreator(e); ok {
+		e.Message = yangErrTable[errtagmap[tag]].msg
+	}
+	return typedError(e).(Formattable), nil
+}
+
+// As NewConstraintError, additionally recording the value which failed
+// the constraint, eg a pattern, range or length statement, with
+// SetOffendingValue.
+func NewConstraintErrorWithValue(path, tag, appTag, message, value string) (Formattable, error) {
+	f, err := NewConstraintError(path, tag, appTag, message)
+	if err != nil {
+		return nil, err
+	}
+	if me, ok := AsMgmtError(f); ok {
+		me.SetOffendingValue(value)
+	}
+	return f, nil
+}
+
+// XPathKind identifies the YANG statement whose XPath expression failed.
+type XPathKind uint
+
+const (
+	XPathMust XPathKind = iota
+	XPathWhen
+)
+
+var xpathKindMap = map[XPathKind]string{
+	XPathMust: "must",
+	XPathWhen: "when",
+}
+
+func (k XPathKind) String() string {
+	return xpathKindMap[k]
+}
+
+// GetXPathKind returns whether the error is the violation of a must or
+// a when statement, ie a MustViolationError or WhenViolationError, which
+// are otherwise both operation-failed errors. false is returned for any
+// other error.
+func (e *MgmtError) GetXPathKind() (XPathKind, bool) {
+	if e.Tag != yang_operation_failed.String() {
+		return 0, false
+	}
+	switch e.AppTag {
+	case must_violation.String():
+		return XPathMust, true
+	case when_violation.String():
+		return XPathWhen, true
+	}
+	return 0, false
+}
+
 // RFC6020 Sect 13.5
 // Error Message for Data That Violates a require-instance Statement
 type InstanceRequiredError struct {
@@ -412,6 +656,16 @@ func (e *InstanceRequiredError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *InstanceRequiredError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *InstanceRequiredError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *InstanceRequiredError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -433,6 +687,39 @@ func NewInstanceRequiredError(path string) *InstanceRequiredError {
 		instance_required.String(), path, needYangPath, nil))
 }
 
+// As NewInstanceRequiredError, additionally recording the instance that
+// the leaf refers to.
+//
+// requiredInstance is the instance-identifier of the non-existing
+// instance.
+func NewInstanceRequiredErrorFor(path, requiredInstance string) *InstanceRequiredError {
+	info := MgmtErrorInfo{
+		*NewMgmtErrorInfoTag(yang_namespace,
+			required_instance_info.String(), requiredInstance),
+	}
+	return createInstanceRequiredError(newYangError(yang_data_missing,
+		instance_required.String(), path, needYangPath, &info))
+}
+
+// RelativeTo returns a copy of the error with its paths, including the
+// required instance, relative to base, as MgmtError.RelativeTo.
+func (e *InstanceRequiredError) RelativeTo(base string) *InstanceRequiredError {
+	return createInstanceRequiredError(e.MgmtError.RelativeTo(base))
+}
+
+func (e *InstanceRequiredError) GetMessage() string {
+	return e.decorate(e.message())
+}
+
+func (e *InstanceRequiredError) message() string {
+	inst := e.Info.FindMgmtErrorTag(yang_namespace,
+		required_instance_info.String())
+	if inst == "" {
+		return e.Message
+	}
+	return formatMessage(InstanceRequiredMessage, inst)
+}
+
 // RFC6020 Sect 13.6
 // Error Message for Data That Does Not Match a leafref Type
 type LeafrefMismatchError struct {
@@ -444,6 +731,16 @@ func (e *LeafrefMismatchError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *LeafrefMismatchError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *LeafrefMismatchError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *LeafrefMismatchError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -463,6 +760,12 @@ func NewLeafrefMismatchError(path, lrefPath string) *LeafrefMismatchError {
 		instance_required.String(), path, lrefPath, nil))
 }
 
+// RelativeTo returns a copy of the error with its path relative to
+// base, as MgmtError.RelativeTo.
+func (e *LeafrefMismatchError) RelativeTo(base string) *LeafrefMismatchError {
+	return createLeafrefMismatchError(e.MgmtError.RelativeTo(base))
+}
+
 // RFC6020 Sect 13.7
 // Error Message for Data That Violates a mandatory choice Statement
 type MissingChoiceError struct {
@@ -474,6 +777,16 @@ func (e *MissingChoiceError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *MissingChoiceError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *MissingChoiceError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *MissingChoiceError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -500,7 +813,7 @@ func NewMissingChoiceError(path, name string) *MissingChoiceError {
 			Value: name,
 		},
 	}
-	return createMissingChoiceError(newYangError(yang_operation_failed,
+	return createMissingChoiceError(newYangError(yang_data_missing,
 		missing_choice.String(), path, needYangPath, &info))
 }
 
@@ -515,6 +828,16 @@ func (e *InsertFailedError) UnmarshalJSON(value []byte) error {
 	return json.Unmarshal(value, e.MgmtError)
 }
 
+func (e *InsertFailedError) GobDecode(data []byte) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.GobDecode(data)
+}
+
+func (e *InsertFailedError) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	e.MgmtError = newMgmtError()
+	return e.MgmtError.UnmarshalXML(dec, start)
+}
+
 func (e *InsertFailedError) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
 	return enc.Encode(e.MgmtError)
 }
@@ -532,3 +855,34 @@ func NewInsertFailedError() *InsertFailedError {
 	return createInsertFailedError(newYangError(yang_bad_attribute,
 		missing_instance.String(), needNodePath, noYangPath, nil))
 }
+
+// As NewInsertFailedError, additionally recording which reference
+// failed.
+//
+// listPath is the absolute XPath expression identifying the list or
+// leaf-list node being edited.
+// attr is the attribute carrying the reference, "key" or "value".
+// value is the referenced, non-existing, key or value.
+func NewInsertFailedErrorFor(listPath, attr, value string) *InsertFailedError {
+	info := MgmtErrorInfo{
+		*NewMgmtErrorInfoTag("", bad_attribute_info.String(), attr),
+		*NewMgmtErrorInfoTag(yang_namespace,
+			insert_value_info.String(), value),
+	}
+	return createInsertFailedError(newYangError(yang_bad_attribute,
+		missing_instance.String(), listPath, noYangPath, &info))
+}
+
+func (e *InsertFailedError) GetMessage() string {
+	return e.decorate(e.message())
+}
+
+func (e *InsertFailedError) message() string {
+	attr := e.Info.FindMgmtErrorTag("", bad_attribute_info.String())
+	value := e.Info.FindMgmtErrorTag(yang_namespace,
+		insert_value_info.String())
+	if attr == "" || value == "" {
+		return e.Message
+	}
+	return formatMessage(InsertFailedMessage, attr, value)
+}
@@ -10,8 +10,10 @@ package mgmterror
 import (
 	"bytes"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"html"
+	"reflect"
 	"testing"
 )
 
@@ -80,6 +82,102 @@ func ExampleNonUniqueError() {
 	// Error: /testcontainer/testlist: Non-unique paths name/dev1/attr/value, name/dev2/attr/value, name/dev3/attr/value
 }
 
+// Info paths are shown relative to the error-path only where they are
+// below it. With no error-path, or one that ends in "/", the paths are
+// still shown whole or relative, rather than with just the leading "/"
+// removed or not trimmed at all.
+func TestNonUniqueErrorRelativePaths(t *testing.T) {
+	paths := []string{
+		"/testcontainer/testlist/name/dev1/attr/value",
+		"/othercontainer/otherlist/name/dev2/attr/value",
+	}
+	tests := []struct {
+		name   string
+		path   string
+		expect string
+	}{
+		{
+			name: "mismatched base",
+			path: "/testcontainer/testlist",
+			expect: "Error: /testcontainer/testlist: Non-unique paths " +
+				"name/dev1/attr/value, " +
+				"/othercontainer/otherlist/name/dev2/attr/value",
+		},
+		{
+			name: "no base",
+			path: "",
+			expect: "Error: : Non-unique paths " +
+				"/testcontainer/testlist/name/dev1/attr/value, " +
+				"/othercontainer/otherlist/name/dev2/attr/value",
+		},
+		{
+			name: "base with trailing slash",
+			path: "/testcontainer/testlist/",
+			expect: "Error: /testcontainer/testlist/: Non-unique paths " +
+				"name/dev1/attr/value, " +
+				"/othercontainer/otherlist/name/dev2/attr/value",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := NewNonUniqueError(paths)
+			err.Path = test.path
+			if err.Error() != test.expect {
+				t.Errorf("Unexpected error string\n  expect: %s\n  actual: %s\n",
+					test.expect, err.Error())
+			}
+		})
+	}
+}
+
+func TestNonUniquePathsOrder(t *testing.T) {
+	paths := []string{
+		"/testcontainer/testlist/name/dev3/attr/value",
+		"/testcontainer/testlist/name/dev1/attr/value",
+		"/testcontainer/testlist/name/dev2/attr/value",
+	}
+	ncerr := NewNonUniqueError(paths)
+	ncerr.Path = "/testcontainer/testlist"
+	ncerr.WithRelatedPaths("/testcontainer/other")
+	if got := ncerr.NonUniquePaths(); !reflect.DeepEqual(got, paths) {
+		t.Errorf("Unexpected paths\n  expect: %v\n  got:    %v",
+			paths, got)
+	}
+
+	marshal, err := json.Marshal(ncerr)
+	if err != nil {
+		t.Fatalf("Marshal NonUniqueError error: %v\n", err)
+	}
+	fromJSON := NonUniqueError{}
+	if err := json.Unmarshal(marshal, &fromJSON); err != nil {
+		t.Fatalf("Unmarshal NonUniqueError error: %v\n", err)
+	}
+	if got := fromJSON.NonUniquePaths(); !reflect.DeepEqual(got, paths) {
+		t.Errorf("Unexpected paths from JSON\n  expect: %v\n  got:    %v",
+			paths, got)
+	}
+
+	marshal, err = xml.Marshal(ncerr)
+	if err != nil {
+		t.Fatalf("Marshal NonUniqueError XML error: %v\n", err)
+	}
+	fromXML := NonUniqueError{newMgmtError()}
+	if err := xml.Unmarshal(marshal, fromXML.MgmtError); err != nil {
+		t.Fatalf("Unmarshal NonUniqueError XML error: %v\n", err)
+	}
+	if got := fromXML.NonUniquePaths(); !reflect.DeepEqual(got, paths) {
+		t.Errorf("Unexpected paths from XML\n  expect: %v\n  got:    %v",
+			paths, got)
+	}
+
+	expect := "Error: /testcontainer/testlist: Non-unique paths " +
+		"name/dev3/attr/value, name/dev1/attr/value, name/dev2/attr/value"
+	if fromXML.Error() != expect {
+		t.Errorf("Unexpected error string\n  expect: %s\n  actual: %s\n",
+			expect, fromXML.Error())
+	}
+}
+
 func genTooManyElementsXml(path string) string {
 	return `<rpc-error xmlns="` + netconf_namespace + `">
 	<error-type>` + html.EscapeString(error_type) + `</error-type>
@@ -110,8 +208,7 @@ func TestTooManyElementsError(t *testing.T) {
 }
 
 func ExampleTooManyElementsError() {
-	err := NewTooManyElementsError("biz")
-	err.Path = "/foo/bar"
+	err := NewTooManyElementsError("/foo/bar")
 	fmt.Println(err.Error())
 
 	// Output:
@@ -147,6 +244,60 @@ func TestTooFewElementsError(t *testing.T) {
 	verifyXmlMarshal(t, ncerr, genTooFewElementsXml(path))
 }
 
+func TestElementsErrorWithCount(t *testing.T) {
+	const path = "/foo/bar/baz"
+	tests := []struct {
+		name   string
+		err    error
+		expMsg string
+	}{
+		{
+			name:   "too many",
+			err:    NewTooManyElementsErrorWithCount(path, 5, 4),
+			expMsg: "list has 5 entries, maximum 4",
+		},
+		{
+			name:   "too few",
+			err:    NewTooFewElementsErrorWithCount(path, 1, 2),
+			expMsg: "list has 1 entries, minimum 2",
+		},
+		{
+			name:   "too many without count",
+			err:    NewTooManyElementsError(path),
+			expMsg: msg_yang_operation_failed,
+		},
+		{
+			name:   "too few without count",
+			err:    NewTooFewElementsError(path),
+			expMsg: msg_yang_operation_failed,
+		},
+	}
+	for _, test := range tests {
+		var list MgmtErrorList
+		list.MgmtErrorListAppend(test.err)
+		marshal, err := json.Marshal(list)
+		if err != nil {
+			t.Errorf("%s: Marshal error: %v\n", test.name, err)
+			continue
+		}
+		var unmarshal MgmtErrorList
+		if err := json.Unmarshal(marshal, &unmarshal); err != nil {
+			t.Errorf("%s: Unmarshal error: %v\n", test.name, err)
+			continue
+		}
+		f, ok := unmarshal.Errors()[0].(Formattable)
+		if !ok {
+			t.Errorf("%s: Unexpected type %T\n", test.name,
+				unmarshal.Errors()[0])
+			continue
+		}
+		if msg := f.GetMessage(); msg != test.expMsg {
+			t.Errorf("%s: Unexpected message\n  expect: %s\n  got:    %s",
+				test.name, test.expMsg, msg)
+		}
+	}
+}
+
 func genMustViolationXml(path string) string {
 	return `<rpc-error xmlns="` + netconf_namespace + `">
 	<error-type>` + html.EscapeString(error_type) + `</error-type>
@@ -177,6 +328,37 @@ func TestMustViolationError(t *testing.T) {
 	verifyXmlMarshal(t, ncerr, genMustViolationXml(path))
 }
 
+func TestMustViolationErrorRelatedPaths(t *testing.T) {
+	related := []string{"/foo/bar/biz", "/foo/bar/boz"}
+	ncerr := NewMustViolationError()
+	ncerr.Path = "/foo/bar/baz"
+	ncerr.WithRelatedPaths(related...)
+	marshal, err := json.MarshalIndent(ncerr, "", "\t")
+	if err != nil {
+		t.Errorf("Marshal MustViolationError error: %v\n", err)
+		return
+	}
+	unmarshal := MustViolationError{}
+	if err := json.Unmarshal(marshal, &unmarshal); err != nil {
+		t.Errorf("Unmarshal MustViolationError error: %v\n", err)
+		return
+	}
+	cmpMgmtError(t, ncerr.MgmtError, unmarshal.MgmtError)
+
+	if paths := unmarshal.GetRelatedPaths(); !reflect.DeepEqual(paths, related) {
+		t.Errorf("Unexpected related paths: %v", paths)
+	}
+	const expMsg = msg_yang_operation_failed +
+		" Related paths: /foo/bar/biz, /foo/bar/boz"
+	if msg := unmarshal.GetMessage(); msg != expMsg {
+		t.Errorf("Unexpected message\n  expect: %s\n  got:    %s",
+			expMsg, msg)
+	}
+	if msg := NewMustViolationError().GetMessage(); msg != msg_yang_operation_failed {
+		t.Errorf("Unexpected message without related paths: %s", msg)
+	}
+}
+
 func genInstanceRequiredXml(path string) string {
 	return `<rpc-error xmlns="` + netconf_namespace + `">
 	<error-type>` + html.EscapeString(error_type) + `</error-type>
@@ -206,6 +388,34 @@ func TestInstanceRequiredError(t *testing.T) {
 	verifyXmlMarshal(t, ncerr, genInstanceRequiredXml(path))
 }
 
+func TestInstanceRequiredErrorFor(t *testing.T) {
+	const (
+		path     = "/foo/bar/baz"
+		instance = "/interfaces/interface[name='dp0s1']"
+	)
+	ncerr := NewInstanceRequiredErrorFor(path, instance)
+	marshal, err := json.MarshalIndent(ncerr, "", "\t")
+	if err != nil {
+		t.Errorf("Marshal InstanceRequiredError error: %v\n", err)
+		return
+	}
+	unmarshal := InstanceRequiredError{}
+	if err := json.Unmarshal(marshal, &unmarshal); err != nil {
+		t.Errorf("Unmarshal InstanceRequiredError error: %v\n", err)
+		return
+	}
+	cmpMgmtError(t, ncerr.MgmtError, unmarshal.MgmtError)
+
+	const expMsg = "require-instance: " + instance + " does not exist"
+	if msg := unmarshal.GetMessage(); msg != expMsg {
+		t.Errorf("Unexpected message\n  expect: %s\n  got:    %s",
+			expMsg, msg)
+	}
+	if msg := NewInstanceRequiredError(path).GetMessage(); msg != msg_yang_data_missing {
+		t.Errorf("Unexpected message without instance: %s", msg)
+	}
+}
+
 func genLeafrefMismatchXml(path string) string {
 	return `<rpc-error xmlns="` + netconf_namespace + `">
 	<error-type>` + html.EscapeString(error_type) + `</error-type>
@@ -235,14 +445,55 @@ func TestLeafrefMismatchError(t *testing.T) {
 	verifyXmlMarshal(t, ncerr, genLeafrefMismatchXml(path))
 }
 
+// The path-bearing YANG errors are rendered relative to the same base,
+// including in their composed messages, and keep their type.
+func TestYangErrorsRelativeTo(t *testing.T) {
+	const base = "/top"
+
+	nonUnique := NewNonUniqueError([]string{
+		"/top/list/a/leaf", "/top/list/b/leaf"})
+	nonUnique.Path = "/top/list"
+	relNonUnique := nonUnique.RelativeTo(base)
+	if relNonUnique.Path != "list" ||
+		!reflect.DeepEqual(relNonUnique.NonUniquePaths(),
+			[]string{"list/a/leaf", "list/b/leaf"}) {
+		t.Errorf("Unexpected non-unique error: %s %v",
+			relNonUnique.Path, relNonUnique.NonUniquePaths())
+	}
+
+	instance := NewInstanceRequiredErrorFor("/top/if/vrf", "/top/vrf/red")
+	relInstance := instance.RelativeTo(base)
+	if relInstance.Path != "if/vrf" {
+		t.Errorf("Unexpected instance-required path: %s",
+			relInstance.Path)
+	}
+	const expMsg = "require-instance: vrf/red does not exist"
+	if msg := relInstance.GetMessage(); msg != expMsg {
+		t.Errorf("Unexpected instance-required message\n"+
+			"  expect: %s\n  got:    %s", expMsg, msg)
+	}
+
+	leafref := NewLeafrefMismatchError("/top/if/vrf", "/top/vrf/red")
+	if relLeafref := leafref.RelativeTo(base); relLeafref.Path != "if/vrf" {
+		t.Errorf("Unexpected leafref path: %s", relLeafref.Path)
+	}
+
+	if nonUnique.Path != "/top/list" || instance.Path != "/top/if/vrf" ||
+		instance.GetMessage() !=
+			"require-instance: /top/vrf/red does not exist" ||
+		leafref.Path != "/top/if/vrf" {
+		t.Errorf("Errors changed by RelativeTo")
+	}
+}
+
 func genMissingChoiceXml(path, name string) string {
 	return `<rpc-error xmlns="` + netconf_namespace + `">
 	<error-type>` + html.EscapeString(error_type) + `</error-type>
-	<error-tag>` + (operation_failed.String()) + `</error-tag>
+	<error-tag>` + html.EscapeString(data_missing.String()) + `</error-tag>
 	<error-severity>` + html.EscapeString(yang_severity_error.String()) + `</error-severity>
 	<error-app-tag>` + html.EscapeString(missing_choice.String()) + `</error-app-tag>
 	<error-path>` + html.EscapeString(path) + `</error-path>
-	<error-message>` + html.EscapeString(msg_yang_operation_failed) + `</error-message>
+	<error-message>` + html.EscapeString(msg_yang_data_missing) + `</error-message>
 	<error-info>
 		<` + missing_choice_info.String() + ` xmlns="` + yang_namespace + `">` + html.EscapeString(name) + `</` + missing_choice_info.String() + `>
 	</error-info>
@@ -296,3 +547,191 @@ func TestInsertFailedError(t *testing.T) {
 
 	verifyXmlMarshal(t, ncerr, genInsertFailedXml())
 }
+
+func TestInsertFailedErrorFor(t *testing.T) {
+	const (
+		path  = "/foo/bar"
+		value = "[name='baz']"
+	)
+	ncerr := NewInsertFailedErrorFor(path, "key", value)
+	marshal, err := json.MarshalIndent(ncerr, "", "\t")
+	if err != nil {
+		t.Errorf("Marshal InsertFailedError error: %v\n", err)
+		return
+	}
+	unmarshal := InsertFailedError{}
+	if err := json.Unmarshal(marshal, &unmarshal); err != nil {
+		t.Errorf("Unmarshal InsertFailedError error: %v\n", err)
+		return
+	}
+	cmpMgmtError(t, ncerr.MgmtError, unmarshal.MgmtError)
+
+	const expMsg = "insert failed: key " + value + " does not exist"
+	if msg := unmarshal.GetMessage(); msg != expMsg {
+		t.Errorf("Unexpected message\n  expect: %s\n  got:    %s",
+			expMsg, msg)
+	}
+	if msg := NewInsertFailedError().GetMessage(); msg != msg_yang_bad_attribute {
+		t.Errorf("Unexpected message without reference: %s", msg)
+	}
+}
+
+func TestYangErrorDispatch(t *testing.T) {
+	tests := []struct {
+		tag, apptag string
+		expected    interface{}
+	}{
+		{"operation-failed", "data-not-unique", &NonUniqueError{}},
+		{"operation-failed", "too-many-elements", &TooManyElementsError{}},
+		{"operation-failed", "too-few-elements", &TooFewElementsError{}},
+		{"operation-failed", "must-violation", &MustViolationError{}},
+		{"data-missing", "instance-required", &InstanceRequiredError{}},
+		{"data-missing", "missing-choice", &MissingChoiceError{}},
+		{"bad-attribute", "missing-instance", &InsertFailedError{}},
+		// App-tags only valid with the tag they are defined for
+		{"data-missing", "missing-instance", nil},
+		{"bad-attribute", "instance-required", nil},
+		{"operation-failed", "missing-choice", nil},
+	}
+
+	// Every entry in the table is tested
+	var entries int
+	for _, errtag := range yangErrTable {
+		entries += len(errtag.apptag)
+	}
+	var found int
+	for _, test := range tests {
+		if test.expected != nil {
+			found++
+		}
+	}
+	if found != entries {
+		t.Errorf("Tested %d of %d table entries", found, entries)
+	}
+
+	for _, test := range tests {
+		err := newMgmtError()
+		err.Typ = error_type
+		err.Tag = test.tag
+		err.AppTag = test.apptag
+		yerr := getYangError(err)
+		if test.expected == nil {
+			if yerr != nil {
+				t.Errorf("%s/%s: unexpected dispatch to %T",
+					test.tag, test.apptag, yerr)
+			}
+			continue
+		}
+		if reflect.TypeOf(yerr) != reflect.TypeOf(test.expected) {
+			t.Errorf("%s/%s: dispatched to %T, expected %T",
+				test.tag, test.apptag, yerr, test.expected)
+		}
+	}
+}
+
+func TestYangErrorConstructorsDispatch(t *testing.T) {
+	errs := []error{
+		NewNonUniqueError([]string{"/a/b", "/a/c"}),
+		NewTooManyElementsError("/a"),
+		NewTooFewElementsError("/a"),
+		NewMustViolationError(),
+		NewInstanceRequiredError("/a"),
+		NewMissingChoiceError("/a", "choice"),
+		NewInsertFailedError(),
+	}
+	for _, err := range errs {
+		me, _ := AsMgmtError(err.(Formattable))
+		if yerr := typedError(me); reflect.TypeOf(yerr) != reflect.TypeOf(err) {
+			t.Errorf("%T dispatched to %T", err, yerr)
+		}
+	}
+}
+
+func TestNewConstraintError(t *testing.T) {
+	tests := []struct {
+		name, tag, appTag, message string
+		expType                    Formattable
+		expMsg                     string
+	}{
+		{"must", "operation-failed", "must-violation",
+			"MTU must be at least 1280 for IPv6",
+			&MustViolationError{},
+			"MTU must be at least 1280 for IPv6"},
+		{"must default message", "operation-failed", "must-violation", "",
+			&MustViolationError{}, msg_yang_operation_failed},
+		{"pattern", "invalid-value", "", "Must be a hostname",
+			&InvalidValueApplicationError{}, "Must be a hostname"},
+		{"custom app-tag", "invalid-value", "mtu-range", "MTU out of range",
+			&InvalidValueApplicationError{}, "MTU out of range"},
+	}
+	for _, test := range tests {
+		err, e := NewConstraintError("/interfaces/dataplane/dp0s1/mtu",
+			test.tag, test.appTag, test.message)
+		if e != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, e)
+			continue
+		}
+		if reflect.TypeOf(err) != reflect.TypeOf(test.expType) {
+			t.Errorf("%s: unexpected type %T", test.name, err)
+			continue
+		}
+		me, _ := AsMgmtError(err)
+		if me.Typ != "application" || me.Tag != test.tag ||
+			me.AppTag != test.appTag ||
+			me.Path != "/interfaces/dataplane/dp0s1/mtu" {
+			t.Errorf("%s: unexpected error %v", test.name, me)
+		}
+		if msg := err.GetMessage(); msg != test.expMsg {
+			t.Errorf("%s: unexpected message %s", test.name, msg)
+		}
+	}
+
+	for _, tag := range []string{"not-a-tag", "lock-denied", ""} {
+		if _, e := NewConstraintError("/a", tag, "", "msg"); e == nil {
+			t.Errorf("Unexpected success creating %q error", tag)
+		}
+	}
+}
+
+func TestYangErrorToNetconf(t *testing.T) {
+	unknownTag := NewMustViolationError().MgmtError
+	unknownTag.Tag = "not-a-netconf-tag"
+	transport := NewMustViolationError().MgmtError
+	transport.Tag = "data-missing"
+	transport.Typ = "transport"
+
+	tests := []struct {
+		name     string
+		err      *MgmtError
+		expected interface{}
+		tag, typ string
+	}{
+		{"operation-failed", NewMustViolationError().MgmtError,
+			&OperationFailedApplicationError{},
+			"operation-failed", "application"},
+		{"data-missing", NewMissingChoiceError("/a", "c").MgmtError,
+			&DataMissingError{}, "data-missing", "application"},
+		{"bad-attribute", NewInsertFailedError().MgmtError,
+			&BadAttrApplicationError{}, "bad-attribute", "application"},
+		{"unknown tag", unknownTag,
+			&OperationFailedApplicationError{},
+			"operation-failed", "application"},
+		{"invalid type", transport,
+			&OperationFailedApplicationError{},
+			"operation-failed", "application"},
+	}
+	for _, test := range tests {
+		nc := test.err.ToNetconf()
+		if nc.AppTag != "" || nc.Tag != test.tag || nc.Typ != test.typ {
+			t.Errorf("%s: unexpected NETCONF error %s/%s/%s",
+				test.name, nc.Typ, nc.Tag, nc.AppTag)
+		}
+		if test.err.AppTag == "" {
+			t.Errorf("%s: original error modified", test.name)
+		}
+		if ncerr := typedError(nc); reflect.TypeOf(ncerr) != reflect.TypeOf(test.expected) {
+			t.Errorf("%s: dispatched to %T, expected %T",
+				test.name, ncerr, test.expected)
+		}
+	}
+}